@@ -1,8 +1,14 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"io/fs"
 	"log"
+	"os"
+	"tech-feed-weekly/internal/canonical"
 	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/internal/dedup"
 	"tech-feed-weekly/internal/feed"
 	"tech-feed-weekly/internal/storage"
 )
@@ -10,11 +16,63 @@ import (
 const (
 	ConfigDir         = "config"
 	LatestItemsPath   = "tmp/data/latest-items.json"
+	HTTPCachePath     = "tmp/data/http-cache.json"
+	HatenaConfigPath  = "settings/hatena-bookmark.json"
+	HatenaHistoryPath = "tmp/data/hatena-bookmark-history.json"
+	FeedHealthPath    = "tmp/data/feed-health.json"
+	URLRulesPath      = "settings/url-canonicalization.json"
 )
 
 func main() {
+	options := feed.DefaultProcessOptions()
+	fetcherConfig := feed.FetcherConfig{}
+	flag.IntVar(&options.Workers, "workers", feed.DefaultWorkers, "number of feeds fetched concurrently")
+	flag.IntVar(&options.MaxPerHost, "max-per-host", feed.DefaultMaxPerHost, "max concurrent requests per host")
+	flag.DurationVar(&options.MaxAge, "max-age", feed.DefaultMaxAge, "ignore entries older than this unless the feed sets maxAge (0 disables the cutoff)")
+	flag.DurationVar(&fetcherConfig.ConnectTimeout, "connect-timeout", feed.DefaultConnectTimeout, "timeout for connecting to a feed host")
+	flag.DurationVar(&fetcherConfig.ReadTimeout, "read-timeout", feed.DefaultReadTimeout, "timeout for reading a feed response")
+	flag.StringVar(&fetcherConfig.UserAgent, "user-agent", feed.DefaultUserAgent, "User-Agent header sent with every request")
+	flag.Int64Var(&fetcherConfig.MaxBodySize, "max-body-size", feed.DefaultMaxBodySize, "max size of a feed response in bytes")
+	flag.IntVar(&fetcherConfig.MaxRetries, "max-retries", feed.DefaultMaxRetries, "retries for transient failures (negative disables retries)")
+	flag.DurationVar(&fetcherConfig.MinRequestInterval, "min-request-interval", feed.DefaultMinRequestInterval, "minimum interval between requests to the same host (negative disables rate limiting)")
+	hatenaConfigPath := flag.String("hatena-config", HatenaConfigPath, "Hatena Bookmark aggregation config file (empty disables Hatena Bookmark)")
+	urlRulesPath := flag.String("url-rules", URLRulesPath, "URL canonicalization rules file")
+	quarantineAfter := flag.Int("quarantine-after", feed.DefaultQuarantineAfter, "consecutive failures after which a feed is skipped (negative disables quarantine)")
+	reprobeInterval := flag.Duration("reprobe-interval", feed.DefaultReprobeInterval, "how often a quarantined feed is tried again")
+	rewriteFeedURLs := flag.Bool("rewrite-feed-urls", true, "rewrite the feedUrl of feeds that moved permanently (301/308)")
+	gitHubTokenEnv := flag.String("github-token-env", feed.DefaultGitHubTokenEnv, "environment variable holding the GitHub API token (unset for unauthenticated requests)")
+	flag.Parse()
+
+	options.KeepFeedURLs = !*rewriteFeedURLs
+	fetcherConfig.GitHubToken = os.Getenv(*gitHubTokenEnv)
+	options.Fetcher = feed.NewFetcher(fetcherConfig)
+
 	log.Println("Starting feed collector...")
 
+	// Load URL canonicalization rules used to compare and store links
+	urlRules, err := config.LoadURLCanonicalizationConfig(*urlRulesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("URL canonicalization rules %s not found, using the default rules", *urlRulesPath)
+	} else if err != nil {
+		log.Fatalf("Failed to load URL canonicalization rules: %v", err)
+	} else {
+		canonical.SetDefault(canonical.New(*urlRules))
+	}
+
+	// Load Hatena Bookmark aggregation settings
+	if *hatenaConfigPath == "" {
+		options.HatenaBookmark = nil
+	} else {
+		hatenaConfig, err := config.LoadHatenaBookmarkConfig(*hatenaConfigPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Hatena Bookmark config %s not found, using the default tech category", *hatenaConfigPath)
+		} else if err != nil {
+			log.Fatalf("Failed to load Hatena Bookmark config: %v", err)
+		} else {
+			options.HatenaBookmark = hatenaConfig
+		}
+	}
+
 	// Load all configuration files from configs directory
 	log.Println("Loading configuration files...")
 	configMap, err := config.LoadAllConfigs(ConfigDir)
@@ -36,14 +94,48 @@ func main() {
 	}
 	log.Printf("Loaded %d existing items", len(existingItems.Items))
 
+	// Load HTTP cache for conditional requests
+	httpCache, err := storage.LoadHTTPCache(HTTPCachePath)
+	if err != nil {
+		log.Printf("Warning: Failed to load HTTP cache, fetching all feeds in full: %v", err)
+		httpCache = nil
+	}
+	options.Fetcher.Cache = feed.NewResponseCache(httpCache)
+
+	// Load Hatena Bookmark counts of previous runs for rising rules
+	hatenaHistory, err := storage.LoadHatenaBookmarkHistory(HatenaHistoryPath)
+	if err != nil {
+		log.Printf("Warning: Failed to load Hatena Bookmark history, starting a new one: %v", err)
+		hatenaHistory = nil
+	}
+	options.HatenaHistory = feed.NewBookmarkHistory(hatenaHistory)
+
+	// Load feed health of previous runs to skip feeds that keep failing
+	feedHealth, err := storage.LoadFeedHealth(FeedHealthPath)
+	if err != nil {
+		log.Printf("Warning: Failed to load feed health, starting a new one: %v", err)
+		feedHealth = nil
+	}
+	options.Health = feed.NewFeedHealth(feedHealth, *quarantineAfter, *reprobeInterval)
+
 	// Process all feeds to find new items and update config files
 	log.Println("Processing feeds to find new items...")
-	newItems, err := feed.ProcessAllFeeds(configMap, existingItems)
+	newItems, err := feed.ProcessAllFeedsWithOptions(configMap, existingItems, options)
 	if err != nil {
 		log.Printf("Warning: Some feeds failed to process: %v", err)
 		// Continue processing even if some feeds failed
 	}
 
+	if err := storage.SaveHTTPCache(HTTPCachePath, options.Fetcher.Cache.Data()); err != nil {
+		log.Printf("Warning: Failed to save HTTP cache: %v", err)
+	}
+	if err := storage.SaveHatenaBookmarkHistory(HatenaHistoryPath, options.HatenaHistory.Data()); err != nil {
+		log.Printf("Warning: Failed to save Hatena Bookmark history: %v", err)
+	}
+	if err := storage.SaveFeedHealth(FeedHealthPath, options.Health.Data()); err != nil {
+		log.Printf("Warning: Failed to save feed health: %v", err)
+	}
+
 	if len(newItems) == 0 {
 		log.Println("No new items found")
 		return
@@ -51,18 +143,22 @@ func main() {
 
 	log.Printf("Found %d new items", len(newItems))
 
-	// Add new items to existing items
-	itemsAdded := 0
-	for _, newItem := range newItems {
-		if storage.AddLatestItem(existingItems, newItem) {
-			itemsAdded++
-			log.Printf("Added new item: %s - %s", newItem.Category, newItem.Title)
-		}
+	// Add new items to existing items, merging the ones that are the same article as an item
+	// collected from another feed (e.g. a company blog and Hatena Bookmark)
+	itemCount := len(existingItems.Items)
+	mergedItems, merged := dedup.MergeNew(existingItems.Items, newItems)
+	existingItems.Items = mergedItems
+	itemsAdded := len(existingItems.Items) - itemCount
+	for _, newItem := range existingItems.Items[itemCount:] {
+		log.Printf("Added new item: %s - %s", newItem.Category, newItem.Title)
+	}
+	if merged > 0 {
+		log.Printf("Merged %d new items collected from other sources into existing items", merged)
 	}
 
 	// Save updated items back to file
-	if itemsAdded > 0 {
-		log.Printf("Saving %d new items to %s", itemsAdded, LatestItemsPath)
+	if itemsAdded > 0 || merged > 0 {
+		log.Printf("Saving %d items to %s", len(existingItems.Items), LatestItemsPath)
 		if err := storage.SaveLatestItems(LatestItemsPath, existingItems); err != nil {
 			log.Fatalf("Failed to save latest items: %v", err)
 		}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/internal/feed"
+	"tech-feed-weekly/pkg/models"
+)
+
+func main() {
+	configPath := flag.String("config", "", "config file the feed is proposed for (e.g. config/company.json)")
+	name := flag.String("name", "", "feed name (defaults to the title of the site)")
+	index := flag.Int("index", 0, "index of the discovered feed to propose when the site has several")
+	appendFeed := flag.Bool("append", false, "append the proposed feed to the config file")
+	userAgent := flag.String("user-agent", feed.DefaultUserAgent, "User-Agent header sent with every request")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <site URL>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *appendFeed && *configPath == "" {
+		log.Fatal("-append requires -config")
+	}
+
+	fetcher := feed.NewFetcher(feed.FetcherConfig{UserAgent: *userAgent})
+
+	log.Printf("Discovering feeds on %s...", flag.Arg(0))
+	feeds, err := fetcher.DiscoverFeeds(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to discover feeds: %v", err)
+	}
+
+	for i, discovered := range feeds {
+		log.Printf("[%d] %s (%s) %s", i, discovered.Title, discovered.Type, discovered.URL)
+	}
+	if *index < 0 || *index >= len(feeds) {
+		log.Fatalf("Invalid -index %d: found %d feeds", *index, len(feeds))
+	}
+
+	feedConfig := feeds[*index].FeedConfig()
+	if *name != "" {
+		feedConfig.Name = *name
+	}
+
+	var configData *config.ConfigFileData
+	if *configPath != "" {
+		configData, err = config.LoadConfigFile(*configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config file: %v", err)
+		}
+		for _, existing := range configData.Data {
+			if existing.FeedURL == feedConfig.FeedURL {
+				log.Fatalf("Feed %s is already configured in %s as %q", feedConfig.FeedURL, *configPath, existing.Name)
+			}
+		}
+		feedConfig.Category = configData.Category
+	}
+
+	if !*appendFeed {
+		if err := printFeedConfig(feedConfig); err != nil {
+			log.Fatalf("Failed to print feed config: %v", err)
+		}
+		return
+	}
+
+	configData.Data = append(configData.Data, feedConfig)
+	if err := config.UpdateConfigFile(configData); err != nil {
+		log.Fatalf("Failed to update config file: %v", err)
+	}
+	log.Printf("Appended %s to %s", feedConfig.Name, *configPath)
+}
+
+// printFeedConfig prints a feed config as a JSON entry ready to be appended to a config file
+func printFeedConfig(feedConfig models.FeedConfig) error {
+	data, err := json.MarshalIndent(feedConfig, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("    %s\n", data)
+	return nil
+}
@@ -107,6 +107,9 @@ func generateHTML(latestItems *models.LatestItems) string {
         li { margin: 10px 0; padding: 8px; background-color: #f8f9fa; border-radius: 4px; }
         a { color: #007acc; text-decoration: none; }
         a:hover { text-decoration: underline; }
+        .meta { margin-left: 8px; color: #666; font-size: 0.85em; }
+        .description { margin: 4px 0 0; color: #444; font-size: 0.9em; }
+        .sources { margin: 4px 0 0; color: #666; font-size: 0.85em; }
         .footer { margin-top: 40px; padding-top: 20px; border-top: 1px solid #ddd; color: #666; font-size: 0.9em; }
     </style>
 </head>
@@ -129,8 +132,8 @@ func generateHTML(latestItems *models.LatestItems) string {
 
 		// Add items
 		for _, item := range items {
-			htmlBuilder.WriteString(fmt.Sprintf("        <li><a href=\"%s\">%s</a></li>\n",
-				escapeHTML(item.Link), escapeHTML(item.Title)))
+			htmlBuilder.WriteString(fmt.Sprintf("        <li><a href=\"%s\">%s</a>%s%s%s</li>\n",
+				escapeHTML(item.Link), escapeHTML(item.Title), formatItemMeta(item), formatItemDescription(item), formatItemSources(item)))
 		}
 
 		htmlBuilder.WriteString("    </ul>\n")
@@ -147,6 +150,70 @@ func generateHTML(latestItems *models.LatestItems) string {
 	return htmlBuilder.String()
 }
 
+// formatItemMeta formats the feed name, publish date, Hatena Bookmark count, GitHub number and kind,
+// author and labels of an item for display
+func formatItemMeta(item models.LatestItem) string {
+	var parts []string
+	if item.FeedName != "" {
+		parts = append(parts, item.FeedName)
+	}
+	if !item.PublishedAt.IsZero() {
+		parts = append(parts, item.PublishedAt.Format("2006-01-02"))
+	}
+	if item.Bookmarks > 0 {
+		parts = append(parts, fmt.Sprintf("%d users", item.Bookmarks))
+	}
+	if item.Number > 0 {
+		kind := "Issue"
+		if item.Kind == "pr" {
+			kind = "PR"
+		}
+		parts = append(parts, fmt.Sprintf("%s #%d", kind, item.Number))
+	}
+	if item.Author != "" {
+		// GitHub issues and pull requests are written by GitHub users
+		if item.Number > 0 {
+			parts = append(parts, "@"+item.Author)
+		} else {
+			parts = append(parts, item.Author)
+		}
+	}
+	if len(item.Labels) > 0 {
+		parts = append(parts, strings.Join(item.Labels, ", "))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` <span class="meta">%s</span>`, escapeHTML(strings.Join(parts, " · ")))
+}
+
+// formatItemDescription formats the description of an item for display
+func formatItemDescription(item models.LatestItem) string {
+	if item.Description == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<p class="description">%s</p>`, escapeHTML(item.Description))
+}
+
+// formatItemSources formats the other sources the same article was collected from,
+// labelled with their feed name (or category when the feed name is unknown)
+func formatItemSources(item models.LatestItem) string {
+	if len(item.Sources) == 0 {
+		return ""
+	}
+
+	var links []string
+	for _, source := range item.Sources {
+		label := source.FeedName
+		if label == "" {
+			label = formatCategoryName(source.Category)
+		}
+		links = append(links, fmt.Sprintf(`<a href="%s" title="%s">%s</a>`, escapeHTML(source.Link), escapeHTML(source.Title), escapeHTML(label)))
+	}
+	return fmt.Sprintf(`<p class="sources">Also on: %s</p>`, strings.Join(links, ", "))
+}
+
 // formatCategoryName formats category name for display
 func formatCategoryName(category string) string {
 	// Convert category names to more readable format
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"tech-feed-weekly/pkg/models"
 	"testing"
+	"time"
 )
 
 func TestFormatCategoryName(t *testing.T) {
@@ -160,4 +161,56 @@ func TestLoadLatestItems_InvalidJSON(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when loading invalid JSON")
 	}
-}
\ No newline at end of file
+}
+
+func TestFormatItemMeta(t *testing.T) {
+	tests := []struct {
+		item     models.LatestItem
+		expected string
+	}{
+		{models.LatestItem{Title: "Article"}, ""},
+		{models.LatestItem{Number: 12, Kind: "pr", Author: "alice", Labels: []string{"bug", "docs"}}, ` <span class="meta">PR #12 · @alice · bug, docs</span>`},
+		{models.LatestItem{Number: 3, Kind: "issue", Author: "bob"}, ` <span class="meta">Issue #3 · @bob</span>`},
+		{models.LatestItem{Number: 4, Labels: []string{"<b>"}}, ` <span class="meta">Issue #4 · &lt;b&gt;</span>`},
+		{models.LatestItem{Title: "Hot entry", Bookmarks: 152}, ` <span class="meta">152 users</span>`},
+		{
+			models.LatestItem{FeedName: "Example Blog", PublishedAt: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC), Author: "Taro"},
+			` <span class="meta">Example Blog · 2025-10-01 · Taro</span>`,
+		},
+	}
+
+	for _, test := range tests {
+		result := formatItemMeta(test.item)
+		if result != test.expected {
+			t.Errorf("formatItemMeta(%+v) = %s, expected %s", test.item, result, test.expected)
+		}
+	}
+}
+
+func TestFormatItemDescription(t *testing.T) {
+	if result := formatItemDescription(models.LatestItem{Title: "Article"}); result != "" {
+		t.Errorf("formatItemDescription() = %s, expected empty string", result)
+	}
+
+	result := formatItemDescription(models.LatestItem{Description: "Use <T> & friends"})
+	expected := `<p class="description">Use &lt;T&gt; &amp; friends</p>`
+	if result != expected {
+		t.Errorf("formatItemDescription() = %s, expected %s", result, expected)
+	}
+}
+
+func TestFormatItemSources(t *testing.T) {
+	if result := formatItemSources(models.LatestItem{Title: "Article"}); result != "" {
+		t.Errorf("formatItemSources() = %s, expected empty string", result)
+	}
+
+	result := formatItemSources(models.LatestItem{Sources: []models.ItemSource{
+		{Title: "Article - Qiita", Link: "https://qiita.com/user/items/abc", Category: "qiita", FeedName: "Qiita User"},
+		{Title: "Article & more", Link: "https://b.example.com/post?a=1&b=2", Category: "hatena-bookmark-tech"},
+	}})
+	expected := `<p class="sources">Also on: <a href="https://qiita.com/user/items/abc" title="Article - Qiita">Qiita User</a>, ` +
+		`<a href="https://b.example.com/post?a=1&amp;b=2" title="Article &amp; more">` + formatCategoryName("hatena-bookmark-tech") + `</a></p>`
+	if result != expected {
+		t.Errorf("formatItemSources() = %s, expected %s", result, expected)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/internal/report"
+	"tech-feed-weekly/internal/storage"
+	"time"
+)
+
+const (
+	ConfigDir      = "config"
+	FeedHealthPath = "tmp/data/feed-health.json"
+)
+
+func main() {
+	var options report.Options
+	flag.IntVar(&options.StaleMonths, "stale-months", report.DefaultStaleMonths, "months without a new item after which a feed is reported as stale")
+	flag.IntVar(&options.TopProducers, "top", report.DefaultTopProducers, "number of most productive feeds listed per category")
+	format := flag.String("format", "table", "output format: table or json")
+	configDir := flag.String("config-dir", ConfigDir, "directory of the feed config files")
+	healthPath := flag.String("health", FeedHealthPath, "feed health state written by the collector")
+	flag.Parse()
+
+	if *format != "table" && *format != "json" {
+		log.Fatalf("Invalid -format %q: must be table or json", *format)
+	}
+
+	configMap, err := config.LoadAllConfigs(*configDir)
+	if err != nil {
+		log.Fatalf("Failed to load configurations: %v", err)
+	}
+
+	health, err := storage.LoadFeedHealth(*healthPath)
+	if err != nil {
+		log.Fatalf("Failed to load feed health: %v", err)
+	}
+	if len(health.Feeds) == 0 {
+		log.Printf("Warning: no feed health in %s, run the collector or download the feed-health artifact first", *healthPath)
+	}
+
+	feedReport := report.Build(configMap, health, time.Now(), options)
+	if *format == "json" {
+		err = report.WriteJSON(os.Stdout, feedReport)
+	} else {
+		err = report.WriteTable(os.Stdout, feedReport)
+	}
+	if err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package canonical
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"tech-feed-weekly/pkg/models"
+)
+
+// DefaultConfig returns the canonicalization rules used when no rules file is given
+func DefaultConfig() models.URLCanonicalizationConfig {
+	return models.URLCanonicalizationConfig{
+		StripParams: []string{
+			"utm_*",
+			"fbclid",
+			"gclid",
+			"mc_cid",
+			"mc_eid",
+			"ref_src",
+		},
+		Domains: map[string]models.URLDomainRule{
+			// Medium appends ?source=rss----<id>---4 to feed links
+			"medium.com": {StripParams: []string{"source"}},
+		},
+	}
+}
+
+// Canonicalizer rewrites URLs to a canonical form so that the same article is recognized
+// regardless of tracking params, fragments, trailing slashes, host case or scheme
+type Canonicalizer struct {
+	config models.URLCanonicalizationConfig
+}
+
+// New creates a Canonicalizer from canonicalization rules
+func New(config models.URLCanonicalizationConfig) *Canonicalizer {
+	return &Canonicalizer{config: config}
+}
+
+var (
+	defaultMu            sync.RWMutex
+	defaultCanonicalizer = New(DefaultConfig())
+)
+
+// SetDefault replaces the Canonicalizer used by URL
+func SetDefault(c *Canonicalizer) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCanonicalizer = c
+}
+
+// URL canonicalizes a link with the default Canonicalizer
+func URL(link string) string {
+	defaultMu.RLock()
+	c := defaultCanonicalizer
+	defaultMu.RUnlock()
+	return c.URL(link)
+}
+
+// Equal reports whether two links are the same after canonicalization with the default Canonicalizer
+func Equal(a string, b string) bool {
+	return URL(a) == URL(b)
+}
+
+// URL canonicalizes a link. Links that are not absolute http(s) URLs are only trimmed
+func (c *Canonicalizer) URL(link string) string {
+	link = strings.TrimSpace(link)
+
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return link
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return link
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+	rule := c.domainRule(host)
+
+	if scheme == "http" && !rule.KeepScheme {
+		scheme = "https"
+		if port == "80" {
+			port = ""
+		}
+	}
+	if (scheme == "https" && port == "443") || (scheme == "http" && port == "80") {
+		port = ""
+	}
+
+	parsed.Scheme = scheme
+	parsed.Host = host
+	if port != "" {
+		parsed.Host = host + ":" + port
+	}
+
+	if !rule.KeepTrailingSlash && len(parsed.Path) > 1 {
+		parsed.Path = strings.TrimRight(parsed.Path, "/")
+		parsed.RawPath = strings.TrimRight(parsed.RawPath, "/")
+		if parsed.Path == "" {
+			parsed.Path = "/"
+			parsed.RawPath = ""
+		}
+	}
+
+	parsed.RawQuery = c.stripQuery(parsed.RawQuery, rule)
+	parsed.ForceQuery = false
+
+	if !rule.KeepFragment {
+		parsed.Fragment = ""
+		parsed.RawFragment = ""
+	}
+
+	return parsed.String()
+}
+
+// stripQuery removes tracking params from a raw query, keeping the order of the remaining params
+func (c *Canonicalizer) stripQuery(rawQuery string, rule models.URLDomainRule) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	var kept []string
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+
+		if !matchesParam(key, rule.KeepParams) &&
+			(matchesParam(key, c.config.StripParams) || matchesParam(key, rule.StripParams)) {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	return strings.Join(kept, "&")
+}
+
+// domainRule returns the rule of the most specific domain matching the host
+func (c *Canonicalizer) domainRule(host string) models.URLDomainRule {
+	var rule models.URLDomainRule
+	matched := ""
+	for domain, domainRule := range c.config.Domains {
+		domain = strings.ToLower(domain)
+		if (host == domain || strings.HasSuffix(host, "."+domain)) && len(domain) > len(matched) {
+			rule = domainRule
+			matched = domain
+		}
+	}
+	return rule
+}
+
+// matchesParam reports whether a query param name matches any of the patterns
+// (case-insensitive, a trailing "*" matches a prefix)
+func matchesParam(key string, patterns []string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package canonical
+
+import (
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalizer_URL(t *testing.T) {
+	canonicalizer := New(DefaultConfig())
+
+	tests := []struct {
+		name     string
+		link     string
+		expected string
+	}{
+		{
+			name:     "tracking params",
+			link:     "https://example.connpass.com/event/123/?utm_campaign=event_message&utm_source=notifications&page=2",
+			expected: "https://example.connpass.com/event/123?page=2",
+		},
+		{
+			name:     "medium source suffix",
+			link:     "https://medium.com/@user/post-abc123?source=rss----1234---4",
+			expected: "https://medium.com/@user/post-abc123",
+		},
+		{
+			name:     "medium subdomain",
+			link:     "https://engineering.medium.com/post?source=rss",
+			expected: "https://engineering.medium.com/post",
+		},
+		{
+			name:     "source param kept outside medium",
+			link:     "https://example.com/download?source=github",
+			expected: "https://example.com/download?source=github",
+		},
+		{
+			name:     "fragment",
+			link:     "https://simonwillison.net/2024/Jan/1/post/#atom-everything",
+			expected: "https://simonwillison.net/2024/Jan/1/post",
+		},
+		{
+			name:     "host case, http and default port",
+			link:     "http://Example.COM:80/Path/",
+			expected: "https://example.com/Path",
+		},
+		{
+			name:     "root path",
+			link:     "https://example.com/",
+			expected: "https://example.com/",
+		},
+		{
+			name:     "surrounding whitespace",
+			link:     "  https://example.com/post  ",
+			expected: "https://example.com/post",
+		},
+		{
+			name:     "not an http URL",
+			link:     "mailto:someone@example.com",
+			expected: "mailto:someone@example.com",
+		},
+		{
+			name:     "relative link",
+			link:     "/posts/1",
+			expected: "/posts/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, canonicalizer.URL(tt.link))
+		})
+	}
+}
+
+func TestCanonicalizer_DomainRules(t *testing.T) {
+	canonicalizer := New(models.URLCanonicalizationConfig{
+		StripParams: []string{"utm_*", "ref"},
+		Domains: map[string]models.URLDomainRule{
+			"example.com":         {KeepParams: []string{"ref"}, KeepTrailingSlash: true},
+			"docs.example.com":    {KeepFragment: true},
+			"legacy.example.org":  {KeepScheme: true},
+			"youtube.example.net": {StripParams: []string{"feature"}},
+		},
+	})
+
+	assert.Equal(t, "https://example.com/post/?ref=top", canonicalizer.URL("https://example.com/post/?ref=top&utm_medium=rss"))
+	// The most specific domain wins
+	assert.Equal(t, "https://docs.example.com/guide#install", canonicalizer.URL("https://docs.example.com/guide/?ref=top#install"))
+	assert.Equal(t, "http://legacy.example.org/post", canonicalizer.URL("http://legacy.example.org/post"))
+	assert.Equal(t, "https://youtube.example.net/watch?v=abc", canonicalizer.URL("https://youtube.example.net/watch?v=abc&feature=share"))
+}
+
+func TestSetDefault(t *testing.T) {
+	defer SetDefault(New(DefaultConfig()))
+
+	assert.True(t, Equal("https://example.com/post?utm_source=rss", "http://EXAMPLE.com/post/"))
+
+	SetDefault(New(models.URLCanonicalizationConfig{}))
+	assert.Equal(t, "https://example.com/post?utm_source=rss", URL("https://example.com/post?utm_source=rss"))
+}
@@ -6,8 +6,10 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"tech-feed-weekly/pkg/models"
+	"time"
 )
 
 // ConfigFileData represents config file data with filename
@@ -32,31 +34,11 @@ func LoadAllConfigs(configDir string) (map[string]*ConfigFileData, error) {
 			return nil
 		}
 
-		// Get filename without extension for category
-		filename := d.Name()
-		category := strings.TrimSuffix(filename, filepath.Ext(filename))
-
-		// Read and parse JSON file
-		data, err := os.ReadFile(path)
+		configData, err := LoadConfigFile(path)
 		if err != nil {
-			return fmt.Errorf("failed to read config file %s: %w", path, err)
-		}
-
-		var feedData models.FeedData
-		if err := json.Unmarshal(data, &feedData); err != nil {
-			return fmt.Errorf("failed to parse JSON file %s: %w", path, err)
-		}
-
-		// Set category for each feed config
-		for i := range feedData.Data {
-			feedData.Data[i].Category = category
-		}
-
-		configMap[category] = &ConfigFileData{
-			FilePath: path,
-			Category: category,
-			Data:     feedData.Data,
+			return err
 		}
+		configMap[configData.Category] = configData
 
 		return nil
 	})
@@ -68,6 +50,35 @@ func LoadAllConfigs(configDir string) (map[string]*ConfigFileData, error) {
 	return configMap, nil
 }
 
+// LoadConfigFile loads a single JSON configuration file
+// The category is the filename without extension
+func LoadConfigFile(path string) (*ConfigFileData, error) {
+	filename := filepath.Base(path)
+	category := strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	// Read and parse JSON file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var feedData models.FeedData
+	if err := json.Unmarshal(data, &feedData); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON file %s: %w", path, err)
+	}
+
+	// Set category for each feed config
+	for i := range feedData.Data {
+		feedData.Data[i].Category = category
+	}
+
+	return &ConfigFileData{
+		FilePath: path,
+		Category: category,
+		Data:     feedData.Data,
+	}, nil
+}
+
 // UpdateConfigFile updates a specific config file with new latest links
 func UpdateConfigFile(configData *ConfigFileData) error {
 	feedData := models.FeedData{
@@ -86,11 +97,64 @@ func UpdateConfigFile(configData *ConfigFileData) error {
 	return nil
 }
 
-// GetAllFeedConfigs extracts all feed configs from the config map
+// GetAllFeedConfigs extracts all feed configs from the config map (categories sorted by name)
 func GetAllFeedConfigs(configMap map[string]*ConfigFileData) []models.FeedConfig {
+	var categories []string
+	for category := range configMap {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
 	var allConfigs []models.FeedConfig
-	for _, configData := range configMap {
-		allConfigs = append(allConfigs, configData.Data...)
+	for _, category := range categories {
+		allConfigs = append(allConfigs, configMap[category].Data...)
 	}
 	return allConfigs
-}
\ No newline at end of file
+}
+
+// LoadHatenaBookmarkConfig loads the Hatena Bookmark aggregation settings from a JSON file
+func LoadHatenaBookmarkConfig(path string) (*models.HatenaBookmarkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Hatena Bookmark config file %s: %w", path, err)
+	}
+
+	var hatenaConfig models.HatenaBookmarkConfig
+	if err := json.Unmarshal(data, &hatenaConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON file %s: %w", path, err)
+	}
+
+	for i, category := range hatenaConfig.Categories {
+		if category.Name == "" {
+			return nil, fmt.Errorf("missing name for Hatena Bookmark category %d in %s", i, path)
+		}
+		if category.Category == "" {
+			return nil, fmt.Errorf("missing target category for Hatena Bookmark category %s in %s", category.Name, path)
+		}
+		if rising := category.Rising; rising != nil {
+			if window, err := time.ParseDuration(rising.Window); err != nil || window <= 0 {
+				return nil, fmt.Errorf("invalid rising window %q for Hatena Bookmark category %s in %s", rising.Window, category.Name, path)
+			}
+			if rising.MinIncrease <= 0 {
+				return nil, fmt.Errorf("invalid rising minIncrease %d for Hatena Bookmark category %s in %s", rising.MinIncrease, category.Name, path)
+			}
+		}
+	}
+
+	return &hatenaConfig, nil
+}
+
+// LoadURLCanonicalizationConfig loads the URL canonicalization rules from a JSON file
+func LoadURLCanonicalizationConfig(path string) (*models.URLCanonicalizationConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read URL canonicalization config file %s: %w", path, err)
+	}
+
+	var rules models.URLCanonicalizationConfig
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON file %s: %w", path, err)
+	}
+
+	return &rules, nil
+}
@@ -179,4 +179,99 @@ func TestGetAllFeedConfigs(t *testing.T) {
 		feedNames[i] = config.Name
 	}
 	assert.ElementsMatch(t, []string{"Feed 1", "Feed 2", "Feed 3"}, feedNames)
-}
\ No newline at end of file
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "company.json")
+
+	err := os.WriteFile(configPath, []byte(`{"data": [{"name": "Example", "type": "feed", "feedUrl": "https://example.com/feed"}]}`), 0644)
+	require.NoError(t, err)
+
+	configData, err := LoadConfigFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, configPath, configData.FilePath)
+	assert.Equal(t, "company", configData.Category)
+	require.Len(t, configData.Data, 1)
+	assert.Equal(t, "Example", configData.Data[0].Name)
+	assert.Equal(t, "company", configData.Data[0].Category)
+}
+
+func TestLoadConfigFile_NonExistentFile(t *testing.T) {
+	configData, err := LoadConfigFile("/non/existent/config.json")
+	assert.Error(t, err)
+	assert.Nil(t, configData)
+}
+
+func TestLoadHatenaBookmarkConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "hatena-bookmark.json")
+
+	err := os.WriteFile(configPath, []byte(`{
+  "categories": [
+    {
+      "name": "it",
+      "category": "hatena-bookmark-tech",
+      "threshold": 120,
+      "domainThresholds": {"speakerdeck.com": 100},
+      "denyDomains": ["zenn.dev"]
+    },
+    {"name": "all", "category": "hatena-bookmark-all", "threshold": 500}
+  ]
+}`), 0644)
+	require.NoError(t, err)
+
+	hatenaConfig, err := LoadHatenaBookmarkConfig(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, hatenaConfig.Categories, 2)
+	assert.Equal(t, models.HatenaBookmarkCategory{
+		Name:             "it",
+		Category:         "hatena-bookmark-tech",
+		Threshold:        120,
+		DomainThresholds: map[string]int{"speakerdeck.com": 100},
+		DenyDomains:      []string{"zenn.dev"},
+	}, hatenaConfig.Categories[0])
+	assert.Equal(t, "all", hatenaConfig.Categories[1].Name)
+	assert.Equal(t, 500, hatenaConfig.Categories[1].Threshold)
+}
+
+func TestLoadHatenaBookmarkConfig_MissingCategory(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "hatena-bookmark.json")
+
+	err := os.WriteFile(configPath, []byte(`{"categories": [{"name": "it", "threshold": 120}]}`), 0644)
+	require.NoError(t, err)
+
+	hatenaConfig, err := LoadHatenaBookmarkConfig(configPath)
+	assert.Error(t, err)
+	assert.Nil(t, hatenaConfig)
+	assert.Contains(t, err.Error(), "missing target category")
+}
+
+func TestLoadHatenaBookmarkConfig_RepositoryFile(t *testing.T) {
+	hatenaConfig, err := LoadHatenaBookmarkConfig("../../settings/hatena-bookmark.json")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hatenaConfig.Categories)
+}
+
+func TestLoadHatenaBookmarkConfig_InvalidRisingRule(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "hatena-bookmark.json")
+
+	err := os.WriteFile(configPath, []byte(`{"categories": [{"name": "it", "category": "hatena-bookmark-tech", "threshold": 120, "rising": {"minIncrease": 50, "window": "3 hours"}}]}`), 0644)
+	require.NoError(t, err)
+
+	hatenaConfig, err := LoadHatenaBookmarkConfig(configPath)
+	assert.Error(t, err)
+	assert.Nil(t, hatenaConfig)
+	assert.Contains(t, err.Error(), "invalid rising window")
+}
+
+func TestLoadURLCanonicalizationConfig(t *testing.T) {
+	rules, err := LoadURLCanonicalizationConfig("../../settings/url-canonicalization.json")
+	require.NoError(t, err)
+	assert.Contains(t, rules.StripParams, "utm_*")
+	assert.Equal(t, []string{"source"}, rules.Domains["medium.com"].StripParams)
+}
@@ -0,0 +1,205 @@
+package dedup
+
+import (
+	"strings"
+	"tech-feed-weekly/internal/canonical"
+	"tech-feed-weekly/pkg/models"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SimilarityThreshold is the minimum title similarity (Dice coefficient of character bigrams)
+// for two items to be considered the same article
+const SimilarityThreshold = 0.8
+
+// minTitleLength is the minimum length of a normalized title for title matching;
+// shorter titles are too generic to be compared
+const minTitleLength = 8
+
+// maxSiteSuffixLength is the maximum length of a trailing " - Site" segment removed from titles
+const maxSiteSuffixLength = 30
+
+// titleSeparators separate an article title from a site name suffix (e.g. "Title - Qiita")
+var titleSeparators = []string{" - ", " | ", " – ", " — ", "｜", " :: "}
+
+// MergeNew adds the new items to the existing items, which are assumed to be merged already.
+// A new item that is the same article as a kept item is recorded in its Sources instead of being added,
+// and a new item with the same canonical URL from the same feed is dropped.
+// Returns the items and the number of new items recorded as sources
+func MergeNew(existing []models.LatestItem, newItems []models.LatestItem) ([]models.LatestItem, int) {
+	merged := append([]models.LatestItem(nil), existing...)
+	titles := make([][]string, len(merged))
+	for i, item := range merged {
+		titles[i] = titleVariants(item.Title)
+	}
+
+	mergedCount := 0
+	for _, item := range newItems {
+		variants := titleVariants(item.Title)
+
+		duplicate := -1
+		for i := range merged {
+			if isDuplicate(merged[i], titles[i], item, variants) {
+				duplicate = i
+				break
+			}
+		}
+
+		if duplicate < 0 {
+			merged = append(merged, item)
+			titles = append(titles, variants)
+			continue
+		}
+
+		representative := &merged[duplicate]
+		if sameFeed(*representative, item) {
+			continue // Collected again from the same feed
+		}
+		mergedCount++
+
+		representative.Sources = append(representative.Sources, models.ItemSource{
+			Title:    item.Title,
+			Link:     item.Link,
+			Category: item.Category,
+			FeedName: item.FeedName,
+		})
+		representative.Sources = append(representative.Sources, item.Sources...)
+		if item.Bookmarks > representative.Bookmarks {
+			representative.Bookmarks = item.Bookmarks
+		}
+		// Fill in the metadata the representative lacks (e.g. a Hatena Bookmark entry has no description)
+		if representative.Description == "" {
+			representative.Description = item.Description
+		}
+		if representative.Author == "" {
+			representative.Author = item.Author
+		}
+		if representative.PublishedAt.IsZero() {
+			representative.PublishedAt = item.PublishedAt
+		}
+	}
+
+	return merged, mergedCount
+}
+
+// isDuplicate reports whether two items are the same article
+// Items from the same feed are only the same article when their canonical URLs are equal,
+// since a feed can publish several entries with similar titles (e.g. pull requests)
+func isDuplicate(a models.LatestItem, aTitles []string, b models.LatestItem, bTitles []string) bool {
+	if canonical.Equal(a.Link, b.Link) {
+		return true
+	}
+	if sameFeed(a, b) {
+		return false
+	}
+
+	for _, aTitle := range aTitles {
+		for _, bTitle := range bTitles {
+			if similarTitles(aTitle, bTitle) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameFeed reports whether two items were collected from the same feed
+// Items stored before the feed name was recorded are never from the same feed
+func sameFeed(a models.LatestItem, b models.LatestItem) bool {
+	return a.FeedName != "" && a.FeedName == b.FeedName
+}
+
+// similarTitles reports whether two normalized titles are similar enough to be the same article
+// Titles with different numbers (versions, episode numbers) are never similar
+func similarTitles(a string, b string) bool {
+	if utf8.RuneCountInString(a) < minTitleLength || utf8.RuneCountInString(b) < minTitleLength {
+		return false
+	}
+	if digits(a) != digits(b) {
+		return false
+	}
+	return Similarity(a, b) >= SimilarityThreshold
+}
+
+// titleVariants returns the normalized title with and without a trailing site name
+func titleVariants(title string) []string {
+	variants := []string{NormalizeTitle(title)}
+
+	trimmed := strings.TrimSpace(title)
+	for _, separator := range titleSeparators {
+		index := strings.LastIndex(trimmed, separator)
+		if index <= 0 {
+			continue
+		}
+		suffix := trimmed[index+len(separator):]
+		if utf8.RuneCountInString(suffix) > maxSiteSuffixLength {
+			continue
+		}
+		if variant := NormalizeTitle(trimmed[:index]); variant != "" {
+			variants = append(variants, variant)
+		}
+	}
+
+	return variants
+}
+
+// NormalizeTitle normalizes a title for comparison: NFKC (full-width to half-width),
+// lower case, and only letters and digits kept
+func NormalizeTitle(title string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFKC.String(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return builder.String()
+}
+
+// Similarity returns the Dice coefficient of the character bigrams of two strings (0 to 1)
+func Similarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	aBigrams := bigrams(a)
+	bBigrams := bigrams(b)
+	total := 0
+	for _, count := range aBigrams {
+		total += count
+	}
+	for _, count := range bBigrams {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	shared := 0
+	for bigram, count := range aBigrams {
+		shared += min(count, bBigrams[bigram])
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// bigrams counts the character bigrams of a string
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	counts := make(map[string]int)
+	for i := 0; i+1 < len(runes); i++ {
+		counts[string(runes[i:i+2])]++
+	}
+	return counts
+}
+
+// digits returns the digits of a string in order
+func digits(s string) string {
+	var builder strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,130 @@
+package dedup
+
+import (
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeNew_Clusters(t *testing.T) {
+	items := []models.LatestItem{
+		{Title: "Goの並行処理パターンを徹底解説", Link: "https://tech.example.com/posts/go-concurrency", Category: "company"},
+		{Title: "Release v1.2.0", Link: "https://github.com/owner/repo/releases/tag/v1.2.0", Category: "oss"},
+		{Title: "Go の並行処理パターンを徹底解説 - Qiita", Link: "https://qiita.com/user/items/abc", Category: "hatena-bookmark-tech", Bookmarks: 150},
+		{Title: "Completely different article", Link: "https://tech.example.com/posts/go-concurrency/?utm_source=rss", Category: "other"},
+		{Title: "Release v1.3.0", Link: "https://github.com/owner/repo/releases/tag/v1.3.0", Category: "oss"},
+	}
+
+	merged, _ := MergeNew(nil, items)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, "Goの並行処理パターンを徹底解説", merged[0].Title)
+	assert.Equal(t, "company", merged[0].Category)
+	assert.Equal(t, 150, merged[0].Bookmarks)
+	assert.Equal(t, []models.ItemSource{
+		{Title: "Go の並行処理パターンを徹底解説 - Qiita", Link: "https://qiita.com/user/items/abc", Category: "hatena-bookmark-tech"},
+		{Title: "Completely different article", Link: "https://tech.example.com/posts/go-concurrency/?utm_source=rss", Category: "other"},
+	}, merged[0].Sources)
+
+	// Titles differing only in their version number are different articles
+	assert.Equal(t, "Release v1.2.0", merged[1].Title)
+	assert.Equal(t, "Release v1.3.0", merged[2].Title)
+	assert.Empty(t, merged[1].Sources)
+}
+
+func TestMergeNew_KeepsSourcesOfMergedItems(t *testing.T) {
+	items := []models.LatestItem{
+		{Title: "Introducing the new runtime API", Link: "https://a.example.com/post", Category: "a"},
+		{
+			Title:    "Introducing the new runtime API | Example Blog",
+			Link:     "https://b.example.com/post",
+			Category: "b",
+			Sources:  []models.ItemSource{{Title: "Introducing the new runtime API", Link: "https://c.example.com/post", Category: "c"}},
+		},
+	}
+
+	merged, _ := MergeNew(nil, items)
+
+	require.Len(t, merged, 1)
+	require.Len(t, merged[0].Sources, 2)
+	assert.Equal(t, "https://b.example.com/post", merged[0].Sources[0].Link)
+	assert.Equal(t, "https://c.example.com/post", merged[0].Sources[1].Link)
+}
+
+func TestMergeNew_FillsMissingMetadata(t *testing.T) {
+	published := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	items := []models.LatestItem{
+		{Title: "Introducing the new runtime API", Link: "https://a.example.com/post", Category: "hatena-bookmark-tech"},
+		{Title: "Introducing the new runtime API", Link: "https://a.example.com/post", Category: "a", Description: "Summary", Author: "Taro", PublishedAt: published},
+	}
+
+	merged, _ := MergeNew(nil, items)
+
+	require.Len(t, merged, 1)
+	assert.Equal(t, "hatena-bookmark-tech", merged[0].Category)
+	assert.Equal(t, "Summary", merged[0].Description)
+	assert.Equal(t, "Taro", merged[0].Author)
+	assert.Equal(t, published, merged[0].PublishedAt)
+}
+
+func TestMergeNew_ShortTitles(t *testing.T) {
+	items := []models.LatestItem{
+		{Title: "Weekly", Link: "https://a.example.com/weekly"},
+		{Title: "Weekly", Link: "https://b.example.com/weekly"},
+	}
+
+	merged, _ := MergeNew(nil, items)
+	assert.Len(t, merged, 2)
+}
+
+func TestMergeNew_SameFeed(t *testing.T) {
+	items := []models.LatestItem{
+		{Title: "feat: add support for Bun runtime", Link: "https://github.com/owner/repo/pull/1", FeedName: "repo PRs"},
+		{Title: "feat: add support for Deno runtime", Link: "https://github.com/owner/repo/pull/2", FeedName: "repo PRs"},
+		{Title: "feat: add support for Bun runtime", Link: "https://github.com/owner/repo/pull/1", FeedName: "repo PRs"},
+	}
+
+	// Similar titles of a single feed are different items, and the same link is only kept once
+	merged, _ := MergeNew(nil, items)
+	require.Len(t, merged, 2)
+	assert.Empty(t, merged[0].Sources)
+	assert.Empty(t, merged[1].Sources)
+}
+
+func TestMergeNew_ExistingItems(t *testing.T) {
+	existing := []models.LatestItem{
+		{Title: "Introducing the new runtime API", Link: "https://a.example.com/post", Category: "a", FeedName: "A Blog"},
+		// Stored items are not merged again
+		{Title: "Introducing the new runtime API!", Link: "https://b.example.com/post", Category: "b", FeedName: "B Blog"},
+	}
+	newItems := []models.LatestItem{
+		{Title: "Introducing the new runtime API - Qiita", Link: "https://qiita.com/user/items/abc", Category: "qiita", FeedName: "Qiita User"},
+		{Title: "Something else entirely", Link: "https://a.example.com/other", Category: "a", FeedName: "A Blog"},
+	}
+
+	merged, count := MergeNew(existing, newItems)
+
+	assert.Equal(t, 1, count)
+	require.Len(t, merged, 3)
+	assert.Equal(t, []models.ItemSource{
+		{Title: "Introducing the new runtime API - Qiita", Link: "https://qiita.com/user/items/abc", Category: "qiita", FeedName: "Qiita User"},
+	}, merged[0].Sources)
+	assert.Empty(t, merged[1].Sources)
+	assert.Equal(t, "Something else entirely", merged[2].Title)
+	assert.Empty(t, existing[0].Sources)
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, "goの並行処理", NormalizeTitle("【Ｇｏ】の 並行処理！"))
+	assert.Equal(t, "hellowww", NormalizeTitle("Hello, W.W.W."))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("abcdef", "abcdef"))
+	assert.Equal(t, 0.0, Similarity("abcdef", "uvwxyz"))
+	assert.InDelta(t, 0.8, Similarity("abcdef", "abcdeg"), 0.001)
+	assert.Equal(t, 0.0, Similarity("", "a"))
+}
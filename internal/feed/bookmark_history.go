@@ -0,0 +1,88 @@
+package feed
+
+import (
+	"sync"
+	"tech-feed-weekly/pkg/models"
+	"time"
+)
+
+// DefaultBookmarkHistoryRetention is how long Hatena Bookmark count samples are kept
+const DefaultBookmarkHistoryRetention = 7 * 24 * time.Hour
+
+// minBookmarkSampleInterval merges samples of the same entry taken within one run
+// (an entry can be listed in several hotentry categories)
+const minBookmarkSampleInterval = time.Minute
+
+// BookmarkHistory records the Hatena Bookmark counts of entries across runs so that
+// the growth of an entry can be measured. A nil *BookmarkHistory records nothing
+type BookmarkHistory struct {
+	mu        sync.Mutex
+	entries   map[string][]models.HatenaBookmarkSample
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewBookmarkHistory creates a BookmarkHistory from persisted history data
+func NewBookmarkHistory(data *models.HatenaBookmarkHistory) *BookmarkHistory {
+	entries := make(map[string][]models.HatenaBookmarkSample)
+	if data != nil {
+		for link, samples := range data.Entries {
+			entries[link] = append([]models.HatenaBookmarkSample(nil), samples...)
+		}
+	}
+	return &BookmarkHistory{
+		entries:   entries,
+		retention: DefaultBookmarkHistoryRetention,
+		now:       time.Now,
+	}
+}
+
+// Data returns a snapshot of the history for persisting, without samples older than the retention
+func (h *BookmarkHistory) Data() *models.HatenaBookmarkHistory {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := h.now().Add(-h.retention)
+	entries := make(map[string][]models.HatenaBookmarkSample, len(h.entries))
+	for link, samples := range h.entries {
+		var kept []models.HatenaBookmarkSample
+		for _, sample := range samples {
+			if !sample.Time.Before(cutoff) {
+				kept = append(kept, sample)
+			}
+		}
+		if len(kept) > 0 {
+			entries[link] = kept
+		}
+	}
+	return &models.HatenaBookmarkHistory{Entries: entries}
+}
+
+// record stores the current bookmark count of an entry and returns how much the count increased
+// since the oldest sample within window (0 when the entry has no earlier sample in the window)
+func (h *BookmarkHistory) record(link string, count int, window time.Duration) int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	increase := 0
+	for _, sample := range h.entries[link] {
+		if window > 0 && !sample.Time.Before(now.Add(-window)) {
+			increase = count - sample.Count
+			break
+		}
+	}
+
+	sample := models.HatenaBookmarkSample{Count: count, Time: now}
+	samples := h.entries[link]
+	if last := len(samples) - 1; last >= 0 && now.Sub(samples[last].Time) < minBookmarkSampleInterval {
+		samples[last] = sample
+	} else {
+		h.entries[link] = append(samples, sample)
+	}
+	return increase
+}
@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBookmarkHistory creates a BookmarkHistory whose clock is controlled by the returned pointer
+func newTestBookmarkHistory(data *models.HatenaBookmarkHistory, start time.Time) (*BookmarkHistory, *time.Time) {
+	now := start
+	history := NewBookmarkHistory(data)
+	history.now = func() time.Time { return now }
+	return history, &now
+}
+
+func TestBookmarkHistory_Record(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	history, now := newTestBookmarkHistory(nil, start)
+	link := "https://example.com/article"
+
+	// No earlier sample
+	assert.Equal(t, 0, history.record(link, 10, 3*time.Hour))
+
+	*now = start.Add(time.Hour)
+	assert.Equal(t, 20, history.record(link, 30, 3*time.Hour))
+
+	*now = start.Add(3 * time.Hour)
+	assert.Equal(t, 60, history.record(link, 70, 3*time.Hour))
+
+	// The first sample is now out of the window, the growth is measured from the second one
+	*now = start.Add(4 * time.Hour)
+	assert.Equal(t, 50, history.record(link, 80, 3*time.Hour))
+
+	// Samples within one run are merged
+	assert.Equal(t, 50, history.record(link, 80, 3*time.Hour))
+	assert.Len(t, history.Data().Entries[link], 4)
+}
+
+func TestBookmarkHistory_NilHistory(t *testing.T) {
+	var history *BookmarkHistory
+	assert.Equal(t, 0, history.record("https://example.com/article", 10, time.Hour))
+}
+
+func TestBookmarkHistory_DataDropsOldSamples(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	data := &models.HatenaBookmarkHistory{Entries: map[string][]models.HatenaBookmarkSample{
+		"https://example.com/old": {{Count: 10, Time: start.Add(-8 * 24 * time.Hour)}},
+		"https://example.com/mixed": {
+			{Count: 10, Time: start.Add(-8 * 24 * time.Hour)},
+			{Count: 20, Time: start.Add(-time.Hour)},
+		},
+	}}
+	history, _ := newTestBookmarkHistory(data, start)
+
+	assert.Equal(t, map[string][]models.HatenaBookmarkSample{
+		"https://example.com/mixed": {{Count: 20, Time: start.Add(-time.Hour)}},
+	}, history.Data().Entries)
+}
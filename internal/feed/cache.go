@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"tech-feed-weekly/pkg/models"
+)
+
+// ErrNotModified is returned when the server answered a conditional request with 304 Not Modified
+var ErrNotModified = errors.New("feed not modified")
+
+// ResponseCache stores the ETag and Last-Modified validators of previous responses per URL
+// so that feeds can be fetched with conditional requests. A nil *ResponseCache disables caching
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]models.HTTPCacheEntry
+}
+
+// NewResponseCache creates a ResponseCache from persisted cache data
+func NewResponseCache(data *models.HTTPCache) *ResponseCache {
+	entries := make(map[string]models.HTTPCacheEntry)
+	if data != nil {
+		for url, entry := range data.Entries {
+			entries[url] = entry
+		}
+	}
+	return &ResponseCache{entries: entries}
+}
+
+// Data returns a snapshot of the cache for persisting
+func (c *ResponseCache) Data() *models.HTTPCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make(map[string]models.HTTPCacheEntry, len(c.entries))
+	for url, entry := range c.entries {
+		entries[url] = entry
+	}
+	return &models.HTTPCache{Entries: entries}
+}
+
+// applyValidators adds If-None-Match/If-Modified-Since headers stored for url to the request
+func (c *ResponseCache) applyValidators(req *http.Request, url string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[url]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// storeValidators remembers the ETag/Last-Modified headers of a successful response
+func (c *ResponseCache) storeValidators(url string, resp *http.Response) {
+	if c == nil {
+		return
+	}
+
+	entry := models.HTTPCacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry.ETag == "" && entry.LastModified == "" {
+		delete(c.entries, url)
+		return
+	}
+	c.entries[url] = entry
+}
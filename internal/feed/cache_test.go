@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessFeedConfig_ConditionalRequest(t *testing.T) {
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>New Article</title>
+      <link>https://example.com/new-article</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 06 Nov 2023 10:00:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Test Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/old-article",
+		Category:   "test",
+	}
+	existingItems := &models.LatestItems{Items: []models.LatestItem{}}
+	cache := NewResponseCache(nil)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+	fetcher.Cache = cache
+
+	// First run fetches the feed in full and remembers the validators
+	result := processFeedConfig(feedConfig, existingItems, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 1)
+
+	entry := cache.Data().Entries[server.URL]
+	assert.Equal(t, `"v1"`, entry.ETag)
+	assert.Equal(t, "Mon, 06 Nov 2023 10:00:00 GMT", entry.LastModified)
+
+	// Second run gets 304 Not Modified, which is not an error
+	feedConfig.LatestLink = "https://example.com/old-article"
+	result = processFeedConfig(feedConfig, existingItems, ProcessOptions{Fetcher: fetcher})
+	assert.NoError(t, result.Error)
+	assert.Empty(t, result.NewItems)
+	assert.False(t, result.ConfigUpdated)
+	assert.Equal(t, 2, requests)
+}
+
+func TestResponseCache_Validators(t *testing.T) {
+	cache := NewResponseCache(&models.HTTPCache{
+		Entries: map[string]models.HTTPCacheEntry{
+			"https://example.com/feed": {ETag: `"abc"`, LastModified: "Mon, 06 Nov 2023 10:00:00 GMT"},
+		},
+	})
+
+	req, err := http.NewRequest("GET", "https://example.com/feed", nil)
+	require.NoError(t, err)
+	cache.applyValidators(req, "https://example.com/feed")
+	assert.Equal(t, `"abc"`, req.Header.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 06 Nov 2023 10:00:00 GMT", req.Header.Get("If-Modified-Since"))
+
+	// Responses without validators remove the entry
+	cache.storeValidators("https://example.com/feed", &http.Response{Header: http.Header{}})
+	assert.Empty(t, cache.Data().Entries)
+
+	// A nil cache is a no-op
+	var nilCache *ResponseCache
+	req, err = http.NewRequest("GET", "https://example.com/feed", nil)
+	require.NoError(t, err)
+	nilCache.applyValidators(req, "https://example.com/feed")
+	assert.Empty(t, req.Header.Get("If-None-Match"))
+}
@@ -0,0 +1,74 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// newFeedDecoder creates an XML decoder for the response body that converts
+// non-UTF-8 feeds (e.g. Shift_JIS, EUC-JP) to UTF-8.
+// The charset of the HTTP Content-Type takes precedence over the XML declaration,
+// unless it claims UTF-8 for a body that is not valid UTF-8
+func newFeedDecoder(resp *http.Response) (*xml.Decoder, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	var reader io.Reader = bytes.NewReader(body)
+	useDeclaration := true
+
+	if label := contentTypeCharset(resp.Header.Get("Content-Type")); label != "" {
+		if isUTF8Label(label) {
+			useDeclaration = !utf8.Valid(body)
+		} else if enc, err := htmlindex.Get(label); err == nil {
+			reader = enc.NewDecoder().Reader(reader)
+			useDeclaration = false
+		}
+	}
+
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		if !useDeclaration {
+			// Already UTF-8, the declared encoding is ignored
+			return input, nil
+		}
+		return charsetReader(label, input)
+	}
+	return decoder, nil
+}
+
+// charsetReader converts input in the named encoding to UTF-8
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	if isUTF8Label(label) {
+		return input, nil
+	}
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q: %w", label, err)
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// contentTypeCharset returns the charset parameter of a Content-Type header
+func contentTypeCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(params["charset"])
+}
+
+// isUTF8Label reports whether the charset label names UTF-8
+func isUTF8Label(label string) bool {
+	label = strings.ToLower(strings.TrimSpace(label))
+	return label == "utf-8" || label == "utf8"
+}
@@ -0,0 +1,96 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// encodeFeed encodes a UTF-8 feed document with the given encoding
+func encodeFeed(t *testing.T, enc encoding.Encoding, doc string) []byte {
+	encoded, err := enc.NewEncoder().String(doc)
+	require.NoError(t, err)
+	return []byte(encoded)
+}
+
+func TestFetchItems_NonUTF8Encodings(t *testing.T) {
+	rssTemplate := func(encodingName string) string {
+		return `<?xml version="1.0" encoding="` + encodingName + `"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>技術ブログ記事</title>
+      <link>https://example.jp/entry</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{
+			name:        "Shift_JIS declared in XML",
+			contentType: "application/rss+xml",
+			body:        encodeFeed(t, japanese.ShiftJIS, rssTemplate("Shift_JIS")),
+		},
+		{
+			name:        "EUC-JP declared in XML",
+			contentType: "text/xml",
+			body:        encodeFeed(t, japanese.EUCJP, rssTemplate("EUC-JP")),
+		},
+		{
+			name:        "Shift_JIS from Content-Type overrides declaration",
+			contentType: "application/rss+xml; charset=Shift_JIS",
+			body:        encodeFeed(t, japanese.ShiftJIS, rssTemplate("UTF-8")),
+		},
+		{
+			name:        "Content-Type claims UTF-8 for a Shift_JIS body",
+			contentType: "text/xml; charset=utf-8",
+			body:        encodeFeed(t, japanese.ShiftJIS, rssTemplate("Shift_JIS")),
+		},
+		{
+			name:        "UTF-8 from Content-Type ignores wrong declaration",
+			contentType: "text/xml; charset=utf-8",
+			body:        []byte(rssTemplate("Shift_JIS")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+			items, err := fetcher.FetchItems(models.FeedConfig{Name: "Japanese Blog", Type: "feed", FeedURL: server.URL})
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, "技術ブログ記事", items[0].Title)
+		})
+	}
+}
+
+func TestCharsetReader_Unsupported(t *testing.T) {
+	_, err := charsetReader("x-unknown-charset", nil)
+	assert.Error(t, err)
+}
+
+func TestContentTypeCharset(t *testing.T) {
+	assert.Equal(t, "Shift_JIS", contentTypeCharset("text/xml; charset=Shift_JIS"))
+	assert.Equal(t, "euc-jp", contentTypeCharset(`application/rss+xml; charset="euc-jp"`))
+	assert.Equal(t, "", contentTypeCharset("application/atom+xml"))
+	assert.Equal(t, "", contentTypeCharset(""))
+}
@@ -0,0 +1,240 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultConnectTimeout is the timeout for establishing a connection (including TLS handshake)
+	DefaultConnectTimeout = 10 * time.Second
+	// DefaultReadTimeout is the timeout for receiving the response after the connection is established
+	DefaultReadTimeout = 30 * time.Second
+	// DefaultMaxBodySize is the maximum size of a response body in bytes
+	DefaultMaxBodySize = 10 << 20
+	// DefaultUserAgent is the User-Agent header sent with every request
+	DefaultUserAgent = "tech-feed-weekly/1.0 (+https://github.com/ysknsid25/tech-feed-weekly)"
+	// DefaultMaxRetries is the number of retries for transient failures
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the backoff delay before the first retry
+	DefaultRetryBaseDelay = 1 * time.Second
+	// DefaultRetryMaxDelay is the upper bound of the backoff delay
+	DefaultRetryMaxDelay = 30 * time.Second
+	// DefaultMaxRetryWait is the longest server requested wait (Retry-After, X-RateLimit-Reset) we accept
+	DefaultMaxRetryWait = 60 * time.Second
+	// DefaultMinRequestInterval is the minimum interval between requests to the same host
+	DefaultMinRequestInterval = 200 * time.Millisecond
+	// DefaultGitHubTokenEnv is the environment variable the GitHub API token is read from
+	DefaultGitHubTokenEnv = "GITHUB_TOKEN"
+)
+
+// ErrGone is returned when the server answered 410 Gone: the feed has been removed for good
+var ErrGone = errors.New("feed is gone")
+
+// BaseURLs holds the base URLs of the built-in sources
+// Overriding them allows pointing the fetcher at a mirror or a test server
+type BaseURLs struct {
+	Zenn           string
+	Note           string
+	Qiita          string
+	Scrapbox       string
+	Connpass       string // The group name is prepended to the host as a subdomain
+	GitHubAPI      string
+	HatenaBookmark string
+}
+
+// DefaultBaseURLs returns the base URLs of the production services
+func DefaultBaseURLs() BaseURLs {
+	return BaseURLs{
+		Zenn:           "https://zenn.dev",
+		Note:           "https://note.com",
+		Qiita:          "https://qiita.com",
+		Scrapbox:       "https://scrapbox.io",
+		Connpass:       "https://connpass.com",
+		GitHubAPI:      "https://api.github.com",
+		HatenaBookmark: "https://b.hatena.ne.jp",
+	}
+}
+
+// FetcherConfig represents the settings of a Fetcher. Zero values fall back to the defaults
+type FetcherConfig struct {
+	ConnectTimeout     time.Duration
+	ReadTimeout        time.Duration
+	UserAgent          string
+	MaxBodySize        int64
+	BaseURLs           *BaseURLs
+	MaxRetries         int           // Negative disables retries
+	RetryBaseDelay     time.Duration // Backoff delay before the first retry
+	RetryMaxDelay      time.Duration // Upper bound of the backoff delay
+	MaxRetryWait       time.Duration // Longest server requested wait we accept before giving up
+	MinRequestInterval time.Duration // Per host, negative disables rate limiting
+	GitHubToken        string        // Token sent to the GitHub API (empty for unauthenticated requests)
+}
+
+// Fetcher fetches feeds over HTTP with timeouts, a User-Agent and a response size limit
+// Transient failures are retried with jittered exponential backoff and requests are rate-limited per host
+type Fetcher struct {
+	Client         *http.Client
+	UserAgent      string
+	MaxBodySize    int64
+	BaseURLs       BaseURLs
+	Cache          *ResponseCache // ETag/Last-Modified cache for conditional requests (nil disables it)
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	MaxRetryWait   time.Duration
+	GitHubToken    string
+
+	moved       *movedFeeds
+	rateLimiter *hostRateLimiter
+	sleep       func(time.Duration)
+}
+
+// defaultFetcher is used by the package level functions
+var defaultFetcher = NewFetcher(FetcherConfig{})
+
+// NewFetcher creates a Fetcher with its own *http.Client
+func NewFetcher(config FetcherConfig) *Fetcher {
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = DefaultConnectTimeout
+	}
+	if config.ReadTimeout <= 0 {
+		config.ReadTimeout = DefaultReadTimeout
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+	baseURLs := DefaultBaseURLs()
+	if config.BaseURLs != nil {
+		baseURLs = *config.BaseURLs
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = DefaultMaxRetries
+	} else if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBaseDelay <= 0 {
+		config.RetryBaseDelay = DefaultRetryBaseDelay
+	}
+	if config.RetryMaxDelay <= 0 {
+		config.RetryMaxDelay = DefaultRetryMaxDelay
+	}
+	if config.MaxRetryWait <= 0 {
+		config.MaxRetryWait = DefaultMaxRetryWait
+	}
+	if config.MinRequestInterval == 0 {
+		config.MinRequestInterval = DefaultMinRequestInterval
+	}
+
+	dialer := &net.Dialer{Timeout: config.ConnectTimeout}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.ConnectTimeout,
+		ResponseHeaderTimeout: config.ReadTimeout,
+		MaxIdleConnsPerHost:   4,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	return &Fetcher{
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   config.ConnectTimeout + config.ReadTimeout,
+		},
+		UserAgent:      config.UserAgent,
+		MaxBodySize:    config.MaxBodySize,
+		BaseURLs:       baseURLs,
+		MaxRetries:     config.MaxRetries,
+		RetryBaseDelay: config.RetryBaseDelay,
+		RetryMaxDelay:  config.RetryMaxDelay,
+		MaxRetryWait:   config.MaxRetryWait,
+		GitHubToken:    config.GitHubToken,
+		moved:          newMovedFeeds(),
+		rateLimiter:    newHostRateLimiter(config.MinRequestInterval),
+		sleep:          time.Sleep,
+	}
+}
+
+// get performs a GET request and returns the response if the status is 200 OK
+// The response body is limited to MaxBodySize and must be closed by the caller.
+// ErrNotModified is returned when the server answered a conditional request with 304
+func (f *Fetcher) get(url string, header http.Header) (*http.Response, error) {
+	return f.getWithCache(url, header, f.Cache)
+}
+
+// getWithCache performs a GET request like get, using the given cache for conditional requests
+// (nil makes an unconditional request)
+func (f *Fetcher) getWithCache(url string, header http.Header, cache *ResponseCache) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		req.Header.Set("User-Agent", f.UserAgent)
+		cache.applyValidators(req, url)
+		return req, nil
+	}
+
+	resp, err := f.doWithRetry(newRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed %s: %w", url, err)
+	}
+
+	if movedTo := permanentRedirectURL(resp); movedTo != "" && movedTo != url {
+		f.moved.record(url, movedTo)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+
+	if resp.StatusCode == http.StatusGone {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP error %d when fetching %s: %w", resp.StatusCode, url, ErrGone)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP error %d when fetching %s", resp.StatusCode, url)
+	}
+
+	resp.Body = &limitedBody{body: resp.Body, remaining: f.MaxBodySize}
+	return resp, nil
+}
+
+// limitedBody is a response body that fails once more than the allowed number of bytes is read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("response body exceeds the size limit")
+	}
+	// Read one byte more than allowed so that oversized bodies can be detected
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, fmt.Errorf("response body exceeds the size limit")
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,84 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFetcher_Defaults(t *testing.T) {
+	fetcher := NewFetcher(FetcherConfig{})
+
+	assert.Equal(t, DefaultUserAgent, fetcher.UserAgent)
+	assert.Equal(t, int64(DefaultMaxBodySize), fetcher.MaxBodySize)
+	assert.Equal(t, DefaultBaseURLs(), fetcher.BaseURLs)
+	assert.Equal(t, DefaultConnectTimeout+DefaultReadTimeout, fetcher.Client.Timeout)
+}
+
+func TestFetcher_BaseURLOverride(t *testing.T) {
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Zenn Article</title>
+      <link>https://zenn.dev/user/articles/1</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	var requestPath, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.Zenn = server.URL
+	fetcher := NewFetcher(FetcherConfig{UserAgent: "test-agent", BaseURLs: &baseURLs})
+
+	item, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Zenn", Type: "zenn", FeedURL: "user", Category: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "Zenn Article", item.Title)
+	assert.Equal(t, "/user/feed", requestPath)
+	assert.Equal(t, "test-agent", userAgent)
+}
+
+func TestFetcher_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel>` +
+			strings.Repeat("<item><title>Article</title></item>", 100) + `</channel></rss>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MaxBodySize: 256})
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Large Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "size limit")
+}
+
+func TestFetcher_ReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{ConnectTimeout: 50 * time.Millisecond, ReadTimeout: 50 * time.Millisecond, MaxRetries: -1})
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Slow Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+	"time"
+)
+
+// jst is Japan Standard Time, assumed for Japanese style dates without a time zone
+var jst = time.FixedZone("JST", 9*60*60)
+
+// namedZones maps the time zone abbreviations found in feeds to their offsets in hours
+// Dates with other abbreviations are not parsed, since Go would silently read them as UTC
+var namedZones = map[string]float64{
+	"UT": 0, "UTC": 0, "GMT": 0, "Z": 0,
+	"JST": 9, "KST": 9, "HKT": 8, "SGT": 8, "IST": 5.5,
+	"CET": 1, "CEST": 2, "BST": 1,
+	"EST": -5, "EDT": -4, "CST": -6, "CDT": -5, "MST": -7, "MDT": -6, "PST": -8, "PDT": -7,
+	"AEST": 10, "AEDT": 11,
+}
+
+// dateLayouts are the layouts of dates in RSS/Atom feeds, tried after the day of week
+// and the time zone have been removed from the value. Dates without a zone are UTC
+var dateLayouts = []string{
+	time.RFC3339Nano, // Atom format: 2006-01-02T15:04:05Z07:00 (fractional seconds optional)
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02T15:04Z07:00", // W3CDTF without seconds (dc:date)
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02", // W3CDTF date only (dc:date)
+	// RFC 822 / RFC 1123 (RSS pubDate) with four or two digit years and optional seconds
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 Jan 06 15:04:05",
+	"2 Jan 06 15:04",
+	"2 January 2006 15:04:05",
+	"2 January 2006 15:04",
+	"Jan 2 2006 15:04:05",
+	"Jan 2 2006",
+	"2 Jan 2006",
+}
+
+// japaneseDateLayouts are the layouts of Japanese style dates. Dates without a zone are JST
+var japaneseDateLayouts = []string{
+	"2006年1月2日 15時4分5秒",
+	"2006年1月2日 15時4分",
+	"2006年1月2日 15:04:05",
+	"2006年1月2日 15:04",
+	"2006年1月2日",
+	"2006/1/2 15:04:05",
+	"2006/1/2 15:04",
+	"2006/1/2",
+	"2006.1.2 15:04",
+	"2006.1.2",
+}
+
+var (
+	dateCommentPattern   = regexp.MustCompile(`\s*[(（][^)）]*[)）]`)
+	dateWeekdayPattern   = regexp.MustCompile(`^[A-Za-z]+\.?,\s*`)
+	dateOffsetPattern    = regexp.MustCompile(`\s*(?:GMT|UTC)?([+-])(\d{1,2}):?(\d{2})$`)
+	dateNamedZonePattern = regexp.MustCompile(`\s+([A-Za-z]{1,5})$`)
+)
+
+// parseDate parses various date formats commonly used in RSS/Atom feeds
+// (RFC 3339, RFC 822 variants, two-digit years, missing seconds, named zones and Japanese formats)
+func parseDate(dateStr string) (time.Time, error) {
+	value, location := normalizeDate(dateStr)
+
+	defaultLocation := location
+	if defaultLocation == nil {
+		defaultLocation = time.UTC
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, defaultLocation); err == nil {
+			return t, nil
+		}
+	}
+
+	if location == nil {
+		location = jst
+	}
+	for _, layout := range japaneseDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+}
+
+// normalizeDate removes comments, the day of week and a trailing time zone from a date,
+// returning the zone as a location (nil if the date has none)
+func normalizeDate(dateStr string) (string, *time.Location) {
+	value := strings.Join(strings.Fields(dateStr), " ")
+	value = dateCommentPattern.ReplaceAllString(value, "")
+	value = dateWeekdayPattern.ReplaceAllString(value, "")
+	value = strings.Replace(value, "Sept ", "Sep ", 1)
+
+	// RFC 3339 dates carry their zone and are parsed as they are
+	if strings.Contains(value, "T") && !strings.Contains(value, " ") {
+		return value, nil
+	}
+
+	if match := dateOffsetPattern.FindStringSubmatch(value); match != nil && strings.Contains(value, " ") {
+		hours, _ := strconv.Atoi(match[2])
+		minutes, _ := strconv.Atoi(match[3])
+		offset := hours*60*60 + minutes*60
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return strings.TrimSpace(strings.TrimSuffix(value, match[0])), time.FixedZone("", offset)
+	}
+
+	if match := dateNamedZonePattern.FindStringSubmatch(value); match != nil {
+		if hours, ok := namedZones[strings.ToUpper(match[1])]; ok {
+			return strings.TrimSuffix(value, match[0]), time.FixedZone(strings.ToUpper(match[1]), int(hours*60*60))
+		}
+	}
+
+	return value, nil
+}
+
+// parseEntryDate parses the date of a feed entry
+// An entry without a date stays undated (zero time). When the date cannot be parsed the entry
+// is undated as well and the date text is returned so that the failure can be reported
+func parseEntryDate(dateStr string) (time.Time, string) {
+	dateStr = strings.TrimSpace(dateStr)
+	if dateStr == "" {
+		return time.Time{}, ""
+	}
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return time.Time{}, dateStr
+	}
+	return date, ""
+}
+
+// sortItemsByDate sorts items by publish date (latest first)
+// Undated items are ranked after the dated ones in document order, so an entry
+// whose date is unknown is never mistaken for the newest one
+func sortItemsByDate(items []models.LatestItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].PublishedAt.After(items[j].PublishedAt)
+	})
+}
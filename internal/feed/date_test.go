@@ -0,0 +1,133 @@
+package feed
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate_Formats(t *testing.T) {
+	expected := time.Date(2023, 11, 6, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dateStr string
+	}{
+		{"RFC 1123 with JST", "Mon, 06 Nov 2023 10:30:00 JST"},
+		{"RFC 1123 with numeric zone", "Mon, 06 Nov 2023 10:30:00 +0900"},
+		{"RFC 822 without day of week", "06 Nov 2023 10:30:00 +0900"},
+		{"RFC 822 two-digit year", "Mon, 06 Nov 23 10:30:00 +0900"},
+		{"RFC 822 without seconds", "Mon, 6 Nov 2023 10:30 +0900"},
+		{"zone with colon", "Mon, 06 Nov 2023 10:30:00 +09:00"},
+		{"zone comment", "Mon, 06 Nov 2023 10:30:00 +0900 (JST)"},
+		{"wrong day of week", "Fri, 06 Nov 2023 10:30:00 +0900"},
+		{"long day of week", "Monday, 06 Nov 2023 10:30:00 +0900"},
+		{"named US zone", "Sun, 05 Nov 2023 21:30:00 EDT"},
+		{"GMT", "Mon, 06 Nov 2023 01:30:00 GMT"},
+		{"RFC 3339", "2023-11-06T10:30:00+09:00"},
+		{"RFC 3339 without seconds", "2023-11-06T10:30+09:00"},
+		{"ISO without colon in zone", "2023-11-06T10:30:00.000+0900"},
+		{"datetime with named zone", "2023-11-06 10:30:00 JST"},
+		{"datetime without seconds", "2023-11-06 01:30"},
+		{"Japanese", "2023年11月6日 10時30分"},
+		{"Japanese with day of week", "2023年11月06日(月) 10:30"},
+		{"slashes", "2023/11/06 10:30"},
+		{"slashes with zone", "2023/11/06 01:30:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDate(tt.dateStr)
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(result), "got %s", result)
+		})
+	}
+}
+
+func TestParseDate_DateOnly(t *testing.T) {
+	result, err := parseDate("2023/11/06")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2023, 11, 6, 0, 0, 0, 0, jst).Equal(result))
+
+	result, err = parseDate("2023-11-06")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC).Equal(result))
+}
+
+func TestParseDate_UnknownZone(t *testing.T) {
+	// Zones missing from namedZones are not read as UTC
+	_, err := parseDate("Mon, 06 Nov 2023 10:30:00 EET")
+	assert.Error(t, err)
+
+	date, unparsed := parseEntryDate("Mon, 06 Nov 2023 10:30:00 EET")
+	assert.True(t, date.IsZero())
+	assert.Equal(t, "Mon, 06 Nov 2023 10:30:00 EET", unparsed)
+}
+
+func TestParseEntryDate(t *testing.T) {
+	date, unparsed := parseEntryDate("")
+	assert.True(t, date.IsZero())
+	assert.Empty(t, unparsed)
+
+	date, unparsed = parseEntryDate(" yesterday ")
+	assert.True(t, date.IsZero())
+	assert.Equal(t, "yesterday", unparsed)
+
+	date, unparsed = parseEntryDate("2023-11-06T10:00:00Z")
+	assert.False(t, date.IsZero())
+	assert.Empty(t, unparsed)
+}
+
+func TestParseRSSFeed_UndatedEntries(t *testing.T) {
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Undated</title>
+      <link>https://example.com/undated</link>
+    </item>
+    <item>
+      <title>Broken Date</title>
+      <link>https://example.com/broken</link>
+      <pubDate>sometime last week</pubDate>
+    </item>
+    <item>
+      <title>Older</title>
+      <link>https://example.com/older</link>
+      <pubDate>Sun, 05 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Latest</title>
+      <link>https://example.com/latest</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(rssXML))}
+	items, err := parseRSSFeed(resp, models.FeedConfig{Category: "test"})
+	require.NoError(t, err)
+
+	// Entries without a usable date never outrank dated ones and keep their document order
+	var links []string
+	for _, item := range items {
+		links = append(links, item.Link)
+	}
+	assert.Equal(t, []string{
+		"https://example.com/latest",
+		"https://example.com/older",
+		"https://example.com/undated",
+		"https://example.com/broken",
+	}, links)
+
+	assert.True(t, items[2].PublishedAt.IsZero())
+	assert.Empty(t, items[2].UnparsedDate)
+	assert.True(t, items[3].PublishedAt.IsZero())
+	assert.Equal(t, "sometime last week", items[3].UnparsedDate)
+}
@@ -0,0 +1,234 @@
+package feed
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"tech-feed-weekly/internal/canonical"
+	"tech-feed-weekly/pkg/models"
+)
+
+// DiscoveredFeed represents a feed found for a web site
+type DiscoveredFeed struct {
+	URL        string
+	Title      string
+	Type       string // FeedConfig type to use for the feed
+	LatestLink string // Link of the latest item at discovery time
+}
+
+// FeedConfig returns a feed configuration for the discovered feed
+// LatestLink is set to the current latest item so that only newer items are collected
+func (d DiscoveredFeed) FeedConfig() models.FeedConfig {
+	return models.FeedConfig{
+		Name:       d.Title,
+		Type:       d.Type,
+		FeedURL:    d.URL,
+		LatestLink: d.LatestLink,
+	}
+}
+
+// commonFeedPaths are probed when a page does not advertise its feeds
+var commonFeedPaths = []string{"/feed", "/rss", "/atom.xml", "/feed.xml", "/rss.xml", "/index.xml", "/feed.json"}
+
+// feedLinkTypes are the media types of <link rel="alternate"> tags pointing to feeds
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/rdf+xml":   true,
+	"application/feed+json": true,
+}
+
+// feedTypesByFormat maps detected formats to the FeedConfig type proposed for them
+// RSS and Atom feeds get the auto-detecting feed type
+var feedTypesByFormat = map[feedFormat]string{
+	formatRSS:  "feed",
+	formatAtom: "feed",
+	formatRDF:  "rdf",
+	formatJSON: "jsonfeed",
+}
+
+var (
+	linkTagPattern   = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z_:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	titleTagPattern  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// feedLink represents a feed advertised by an HTML page
+type feedLink struct {
+	URL   string
+	Title string
+}
+
+// DiscoverFeeds finds the feeds of a web site from the <link rel="alternate"> tags of the page,
+// probing common feed paths when the page advertises none. Every candidate is fetched to
+// verify that it is a feed. If pageURL is a feed itself, it is returned as the only result
+func (f *Fetcher) DiscoverFeeds(pageURL string) ([]DiscoveredFeed, error) {
+	baseURL, err := url.Parse(pageURL)
+	if err != nil || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid page URL %s", pageURL)
+	}
+
+	resp, err := f.get(pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	contentType := resp.Header.Get("Content-Type")
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page %s: %w", pageURL, err)
+	}
+
+	pageTitle := htmlTitle(body)
+	if pageTitle == "" {
+		pageTitle = baseURL.Host
+	}
+
+	// The URL may already point to a feed
+	if detectFeedFormat(contentType, body) != formatUnknown {
+		feed, err := verifyFeed(pageURL, contentType, body)
+		if err != nil {
+			return nil, err
+		}
+		feed.Title = baseURL.Host
+		return []DiscoveredFeed{feed}, nil
+	}
+
+	candidates := advertisedFeedLinks(body, baseURL)
+	if len(candidates) == 0 {
+		log.Printf("No feed links found on %s, probing common paths", pageURL)
+		for _, path := range commonFeedPaths {
+			candidates = append(candidates, feedLink{URL: baseURL.ResolveReference(&url.URL{Path: path}).String()})
+		}
+	}
+
+	var feeds []DiscoveredFeed
+	for _, candidate := range candidates {
+		feed, err := f.probeFeed(candidate.URL)
+		if err != nil {
+			log.Printf("Skipping %s: %v", candidate.URL, err)
+			continue
+		}
+		feed.Title = candidate.Title
+		if feed.Title == "" {
+			feed.Title = pageTitle
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("no feeds found for %s", pageURL)
+	}
+	return feeds, nil
+}
+
+// probeFeed fetches a candidate URL and verifies that it is a feed
+func (f *Fetcher) probeFeed(feedURL string) (DiscoveredFeed, error) {
+	resp, err := f.get(feedURL, nil)
+	if err != nil {
+		return DiscoveredFeed{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DiscoveredFeed{}, fmt.Errorf("failed to read feed: %w", err)
+	}
+	return verifyFeed(feedURL, resp.Header.Get("Content-Type"), body)
+}
+
+// verifyFeed parses a feed document and returns it as a discovered feed
+// A document that cannot be parsed is not a working feed and returns an error
+func verifyFeed(feedURL string, contentType string, body []byte) (DiscoveredFeed, error) {
+	format := detectFeedFormat(contentType, body)
+	feedType, ok := feedTypesByFormat[format]
+	if !ok {
+		return DiscoveredFeed{}, fmt.Errorf("not a feed")
+	}
+
+	feed := DiscoveredFeed{URL: feedURL, Type: feedType}
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": []string{contentType}},
+		Body:   io.NopCloser(bytes.NewReader(body)),
+	}
+	items, err := feedParsers[format](resp, models.FeedConfig{})
+	if err != nil {
+		return DiscoveredFeed{}, err
+	}
+	if len(items) > 0 {
+		feed.LatestLink = canonical.URL(items[0].Link)
+	}
+	return feed, nil
+}
+
+// advertisedFeedLinks extracts the feeds advertised with <link rel="alternate" type="..."> tags
+func advertisedFeedLinks(body []byte, baseURL *url.URL) []feedLink {
+	var links []feedLink
+	seen := make(map[string]bool)
+
+	for _, tag := range linkTagPattern.FindAll(body, -1) {
+		attributes := htmlAttributes(tag)
+
+		if !hasToken(attributes["rel"], "alternate") {
+			continue
+		}
+		mediaType := strings.ToLower(strings.TrimSpace(attributes["type"]))
+		if !feedLinkTypes[mediaType] {
+			continue
+		}
+
+		href, err := url.Parse(strings.TrimSpace(attributes["href"]))
+		if err != nil || attributes["href"] == "" {
+			continue
+		}
+		feedURL := baseURL.ResolveReference(href).String()
+		if seen[feedURL] {
+			continue
+		}
+		seen[feedURL] = true
+
+		links = append(links, feedLink{
+			URL:   feedURL,
+			Title: strings.TrimSpace(attributes["title"]),
+		})
+	}
+
+	return links
+}
+
+// htmlAttributes parses the attributes of an HTML tag (names are lower-cased, values unescaped)
+func htmlAttributes(tag []byte) map[string]string {
+	attributes := make(map[string]string)
+	for _, match := range attributePattern.FindAllSubmatch(tag, -1) {
+		name := strings.ToLower(string(match[1]))
+		value := string(bytes.Join([][]byte{match[2], match[3], match[4]}, nil))
+		attributes[name] = html.UnescapeString(value)
+	}
+	return attributes
+}
+
+// hasToken reports whether a space separated attribute value contains the token
+func hasToken(value string, token string) bool {
+	for _, field := range strings.Fields(strings.ToLower(value)) {
+		if field == token {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlTitle returns the content of the <title> tag of an HTML page
+func htmlTitle(body []byte) string {
+	match := titleTagPattern.FindSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(string(match[1])))
+}
@@ -0,0 +1,172 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const discoverRSSFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Example Blog</title>
+    <item>
+      <title>Latest Post</title>
+      <link>https://example.com/posts/latest</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+const discoverAtomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Blog</title>
+  <entry>
+    <title>Latest Entry</title>
+    <link href="https://example.com/entries/latest"/>
+    <updated>2023-11-06T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+func TestDiscoverFeeds_LinkTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head>
+  <title>Example &amp; Co</title>
+  <link rel="stylesheet" href="/style.css">
+  <link rel="alternate" type="application/rss+xml" title="Example RSS" href="/rss.xml">
+  <LINK REL='alternate' TYPE='application/atom+xml' HREF='atom.xml'>
+  <link rel="alternate" hreflang="en" href="/en/">
+  <link rel="alternate" type="application/rss+xml" href="/broken.xml">
+  <link rel="alternate" type="application/rss+xml" href="/invalid.xml">
+</head>
+<body></body>
+</html>`)
+		case "/rss.xml":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, discoverRSSFeed)
+		case "/atom.xml":
+			w.Header().Set("Content-Type", "application/atom+xml")
+			fmt.Fprint(w, discoverAtomFeed)
+		case "/invalid.xml":
+			// A feed Content-Type with a body that cannot be parsed is not proposed
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, `<rss version="2.0"><channel><item><title>Broken`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MaxRetries: -1, MinRequestInterval: -1})
+	feeds, err := fetcher.DiscoverFeeds(server.URL + "/")
+	require.NoError(t, err)
+
+	require.Len(t, feeds, 2)
+	assert.Equal(t, DiscoveredFeed{
+		URL:        server.URL + "/rss.xml",
+		Title:      "Example RSS",
+		Type:       "feed",
+		LatestLink: "https://example.com/posts/latest",
+	}, feeds[0])
+	assert.Equal(t, DiscoveredFeed{
+		URL:        server.URL + "/atom.xml",
+		Title:      "Example & Co",
+		Type:       "feed",
+		LatestLink: "https://example.com/entries/latest",
+	}, feeds[1])
+}
+
+func TestDiscoverFeeds_ProbesCommonPaths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/blog":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><head><title>No Links</title></head></html>`)
+		case "/feed":
+			// Some sites answer every path with an HTML page
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html></html>`)
+		case "/atom.xml":
+			fmt.Fprint(w, discoverAtomFeed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MaxRetries: -1, MinRequestInterval: -1})
+	feeds, err := fetcher.DiscoverFeeds(server.URL + "/blog")
+	require.NoError(t, err)
+
+	require.Len(t, feeds, 1)
+	assert.Equal(t, server.URL+"/atom.xml", feeds[0].URL)
+	assert.Equal(t, "No Links", feeds[0].Title)
+	assert.Equal(t, "feed", feeds[0].Type)
+}
+
+func TestDiscoverFeeds_FeedURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		fmt.Fprint(w, `{"version": "https://jsonfeed.org/version/1.1", "title": "JSON", "items": [{"id": "1", "url": "https://example.com/1", "title": "One"}]}`)
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MaxRetries: -1, MinRequestInterval: -1})
+	feeds, err := fetcher.DiscoverFeeds(server.URL + "/feed.json")
+	require.NoError(t, err)
+
+	require.Len(t, feeds, 1)
+	assert.Equal(t, "jsonfeed", feeds[0].Type)
+	assert.Equal(t, "https://example.com/1", feeds[0].LatestLink)
+
+	config := feeds[0].FeedConfig()
+	assert.Equal(t, "jsonfeed", config.Type)
+	assert.Equal(t, server.URL+"/feed.json", config.FeedURL)
+	assert.Equal(t, "https://example.com/1", config.LatestLink)
+}
+
+func TestDiscoverFeeds_NoFeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<html><head><title>Nothing</title></head></html>`)
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MaxRetries: -1, MinRequestInterval: -1})
+	feeds, err := fetcher.DiscoverFeeds(server.URL + "/")
+	assert.Error(t, err)
+	assert.Nil(t, feeds)
+	assert.Contains(t, err.Error(), "no feeds found")
+}
+
+func TestAdvertisedFeedLinks(t *testing.T) {
+	baseURL, err := url.Parse("https://example.com/blog/")
+	require.NoError(t, err)
+
+	body := []byte(`<head>
+<link rel="alternate" type="application/rss+xml" href="feed.xml">
+<link rel="alternate feed" type="application/feed+json" href="https://cdn.example.com/feed.json?a=1&amp;b=2" title="JSON">
+<link rel="alternate" type="application/rss+xml" href="/blog/feed.xml">
+<link rel="canonical" type="application/rss+xml" href="/ignored.xml">
+<link rel="alternate" type="text/html" href="/ignored.html">
+</head>`)
+
+	links := advertisedFeedLinks(body, baseURL)
+	assert.Equal(t, []feedLink{
+		{URL: "https://example.com/blog/feed.xml"},
+		{URL: "https://cdn.example.com/feed.json?a=1&b=2", Title: "JSON"},
+	}, links)
+}
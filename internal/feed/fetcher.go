@@ -1,275 +1,263 @@
 package feed
 
 import (
-	"encoding/json"
-	"encoding/xml"
 	"fmt"
-	"net/http"
-	"sort"
+	"log"
+	"net/url"
 	"strings"
+	"tech-feed-weekly/internal/canonical"
 	"tech-feed-weekly/pkg/models"
 	"time"
 )
 
+// DefaultMaxNewItems is the maximum number of new items collected from a single feed per run
+// when the feed configuration does not specify its own limit
+const DefaultMaxNewItems = 10
+
 // FetchLatestItem fetches the latest item from RSS/Atom feed or GitHub Issues
 func FetchLatestItem(feedConfig models.FeedConfig) (*models.LatestItem, error) {
-	if feedConfig.Type == "github-issues" {
-		return fetchLatestGitHubIssue(feedConfig)
-	}
-
-	feedURL := getFeedURL(feedConfig)
-	if feedURL == "" {
-		return nil, fmt.Errorf("could not generate feed URL for %s", feedConfig.Name)
-	}
-
-	isAtomFormat := isAtomFeed(feedConfig.Type)
+	return defaultFetcher.FetchLatestItem(feedConfig)
+}
 
-	// Fetch the feed
-	resp, err := http.Get(feedURL)
+// FetchLatestItem fetches the latest item from RSS/Atom feed or GitHub Issues
+func (f *Fetcher) FetchLatestItem(feedConfig models.FeedConfig) (*models.LatestItem, error) {
+	items, err := f.FetchItems(feedConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch feed %s: %w", feedURL, err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error %d when fetching %s", resp.StatusCode, feedURL)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no items found for %s", feedConfig.Name)
 	}
-
-	if isAtomFormat {
-		return parseAtomFeed(resp, feedConfig)
-	}
-	return parseRSSFeed(resp, feedConfig)
+	return &items[0], nil
 }
 
-// fetchLatestGitHubIssue fetches the latest issue from a GitHub repository
-func fetchLatestGitHubIssue(config models.FeedConfig) (*models.LatestItem, error) {
-	apiURL := getFeedURL(config)
-	if apiURL == "" {
-		return nil, fmt.Errorf("could not generate feed URL for %s", config.Name)
-	}
-
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", apiURL, err)
-	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+// FetchNewItems fetches all items newer than feedConfig.LatestLink (latest first),
+// returning at most maxItems items. If maxItems is zero or negative, DefaultMaxNewItems is used.
+// When LatestLink is empty or no longer present in the feed, only the latest item is returned.
+func FetchNewItems(feedConfig models.FeedConfig, maxItems int) ([]models.LatestItem, error) {
+	return defaultFetcher.FetchNewItems(feedConfig, maxItems)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// FetchNewItems fetches all items newer than feedConfig.LatestLink (latest first),
+// returning at most maxItems items
+func (f *Fetcher) FetchNewItems(feedConfig models.FeedConfig, maxItems int) ([]models.LatestItem, error) {
+	items, err := f.FetchItems(feedConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch github issues %s: %w", apiURL, err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return selectNewItems(items, feedConfig, maxItems), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error %d when fetching %s", resp.StatusCode, apiURL)
+// selectNewItems picks the items that are newer than the recorded latest link
+func selectNewItems(items []models.LatestItem, feedConfig models.FeedConfig, maxItems int) []models.LatestItem {
+	if maxItems <= 0 {
+		maxItems = DefaultMaxNewItems
 	}
 
-	var issues []models.GitHubIssue
-	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
-		return nil, fmt.Errorf("failed to decode GitHub issues JSON: %w", err)
+	if len(items) == 0 {
+		return nil
 	}
-
-	if len(issues) == 0 {
-		return nil, fmt.Errorf("no issues found for %s", config.FeedURL)
+	if feedConfig.LatestLink == "" {
+		return items[:1]
 	}
 
-	latestIssue := issues[0]
-
-	return &models.LatestItem{
-		Title:    strings.TrimSpace(latestIssue.Title),
-		Link:     strings.TrimSpace(latestIssue.HTMLURL),
-		Category: config.Category,
-	}, nil
-}
-
-// getFeedURL generates the appropriate feed URL based on the feed type
-func getFeedURL(config models.FeedConfig) string {
-	switch config.Type {
-	case "zenn":
-		return fmt.Sprintf("https://zenn.dev/%s/feed", config.FeedURL)
-	case "note":
-		return fmt.Sprintf("https://note.com/%s/rss", config.FeedURL)
-	case "qiita":
-		return fmt.Sprintf("https://qiita.com/%s/feed", config.FeedURL)
-	case "hatena":
-		return fmt.Sprintf("%s/rss", config.FeedURL)
-	case "scrapbox":
-		return fmt.Sprintf("https://scrapbox.io/api/feed/%s", config.FeedURL)
-	case "connpass":
-		return fmt.Sprintf("https://%s.connpass.com/ja.atom", config.FeedURL)
-	case "categoryIsUrl", "categoryIsAtomUrl":
-		return config.FeedURL
-	case "github-issues":
-		return fmt.Sprintf("https://api.github.com/repos/%s/issues?state=open&sort=created&direction=desc", config.FeedURL)
-	default:
-		return ""
+	latestLink := canonical.URL(feedConfig.LatestLink)
+	for i, item := range items {
+		if canonical.URL(item.Link) == latestLink {
+			if i > maxItems {
+				log.Printf("Limiting new items for %s to %d (found %d)", feedConfig.Name, maxItems, i)
+				i = maxItems
+			}
+			return items[:i]
+		}
 	}
-}
 
-// isAtomFeed determines if the feed type is Atom format
-func isAtomFeed(feedType string) bool {
-	return feedType == "qiita" || feedType == "connpass" || feedType == "categoryIsAtomUrl"
+	// The recorded link has dropped out of the feed (or was rewritten), so we cannot tell
+	// which items are new. Fall back to the latest item only to avoid flooding.
+	log.Printf("Latest link for %s not found in feed, falling back to the latest item", feedConfig.Name)
+	return items[:1]
 }
 
-// parseRSSFeed parses RSS feed and returns the latest item
-func parseRSSFeed(resp *http.Response, config models.FeedConfig) (*models.LatestItem, error) {
-	var feed models.RSSFeed
-	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
-		return nil, fmt.Errorf("failed to decode RSS feed: %w", err)
+// FetchItems fetches all items from the feed sorted by date (latest first)
+// using the source registered for the feed type. Item links are canonicalized
+// and items are tagged with the feed name.
+// If the fetcher has a cache, the request is made conditionally and ErrNotModified is returned
+// when the feed has not changed since the previous run
+func (f *Fetcher) FetchItems(feedConfig models.FeedConfig) ([]models.LatestItem, error) {
+	source, ok := LookupSource(feedConfig.Type)
+	if !ok {
+		return nil, fmt.Errorf("could not generate feed URL for %s: unknown feed type %q", feedConfig.Name, feedConfig.Type)
 	}
-
-	if len(feed.Channel.Items) == 0 {
-		return nil, fmt.Errorf("no items found in RSS feed")
+	items, err := source.Fetch(f, feedConfig)
+	if err != nil {
+		return nil, err
 	}
-
-	// Parse dates and find the latest item
-	var items []models.RSSItem
-	for _, item := range feed.Channel.Items {
-		parsedDate, err := parseDate(item.PubDate)
-		if err != nil {
-			// If date parsing fails, skip this item or use current time
-			parsedDate = time.Now()
-		}
-		item.Date = parsedDate
-		items = append(items, item)
+	for i := range items {
+		items[i].Link = canonical.URL(items[i].Link)
+		items[i].FeedName = feedConfig.Name
 	}
-
-	// Sort by date (latest first)
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Date.After(items[j].Date)
-	})
-
-	latestItem := items[0]
-	return &models.LatestItem{
-		Title:    strings.TrimSpace(latestItem.Title),
-		Link:     strings.TrimSpace(latestItem.Link),
-		Category: config.Category,
-	}, nil
+	return items, nil
 }
 
-// parseAtomFeed parses Atom feed and returns the latest item
-func parseAtomFeed(resp *http.Response, config models.FeedConfig) (*models.LatestItem, error) {
-	var feed models.AtomFeed
-	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
-		return nil, fmt.Errorf("failed to decode Atom feed: %w", err)
+// fetchFeed fetches a feed document and parses it with the given parser
+func (f *Fetcher) fetchFeed(feedURL string, feedConfig models.FeedConfig, parse feedParser) ([]models.LatestItem, error) {
+	if feedURL == "" {
+		return nil, fmt.Errorf("could not generate feed URL for %s", feedConfig.Name)
 	}
 
-	if len(feed.Entries) == 0 {
-		return nil, fmt.Errorf("no entries found in Atom feed")
+	// Fetch the feed
+	resp, err := f.get(feedURL, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	// Parse dates and find the latest entry
-	var entries []models.AtomEntry
-	for _, entry := range feed.Entries {
-		parsedDate, err := parseDate(entry.Updated)
-		if err != nil {
-			// If date parsing fails, skip this entry or use current time
-			parsedDate = time.Now()
-		}
-		entry.Date = parsedDate
-		entries = append(entries, entry)
+	items, err := parse(resp, feedConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	// Sort by date (latest first)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Date.After(entries[j].Date)
-	})
-
-	latestEntry := entries[0]
-	return &models.LatestItem{
-		Title:    strings.TrimSpace(latestEntry.Title),
-		Link:     strings.TrimSpace(latestEntry.Link.Href),
-		Category: config.Category,
-	}, nil
+	// Only remember the validators once the response has been parsed successfully
+	f.Cache.storeValidators(feedURL, resp)
+	return items, nil
 }
 
-// parseDate parses various date formats commonly used in RSS/Atom feeds
-func parseDate(dateStr string) (time.Time, error) {
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common date formats in RSS/Atom feeds
-	formats := []string{
-		time.RFC1123,     // RSS format: Mon, 02 Jan 2006 15:04:05 MST
-		time.RFC1123Z,    // RSS format with numeric timezone
-		time.RFC3339,     // Atom format: 2006-01-02T15:04:05Z07:00
-		time.RFC3339Nano, // Atom format with nanoseconds
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
-		"Mon, 2 Jan 2006 15:04:05 MST",
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-	}
+// getFeedURL generates the appropriate feed URL based on the feed type using the default base URLs
+func getFeedURL(config models.FeedConfig) string {
+	return defaultFetcher.feedURL(config)
+}
 
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return t, nil
-		}
+// feedURL generates the appropriate feed URL based on the feed type
+func (f *Fetcher) feedURL(config models.FeedConfig) string {
+	source, ok := LookupSource(config.Type)
+	if !ok {
+		return ""
 	}
+	return source.FeedURL(f.BaseURLs, config)
+}
 
-	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+// techHatenaBookmarkCategory is the Hatena Bookmark tech category with the thresholds used before they became configurable
+var techHatenaBookmarkCategory = models.HatenaBookmarkCategory{
+	Name:             "it",
+	Category:         "hatena-bookmark-tech",
+	Threshold:        120,
+	DomainThresholds: map[string]int{"speakerdeck.com": 100},
+	DenyDomains:      []string{"zenn.dev"},
 }
 
 // FetchHatenaBookmarkTechCategoryItems fetches items from Hatena Bookmark tech category RSS
 // and filters them based on bookmark count and site-specific thresholds
 func FetchHatenaBookmarkTechCategoryItems() ([]models.LatestItem, error) {
-	url := "https://b.hatena.ne.jp/hotentry/it.rss"
+	return defaultFetcher.FetchHatenaBookmarkTechCategoryItems()
+}
+
+// FetchHatenaBookmarkTechCategoryItems fetches items from Hatena Bookmark tech category RSS
+// and filters them based on bookmark count and site-specific thresholds
+func (f *Fetcher) FetchHatenaBookmarkTechCategoryItems() ([]models.LatestItem, error) {
+	return f.FetchHatenaBookmarkItems(techHatenaBookmarkCategory)
+}
 
-	resp, err := http.Get(url)
+// FetchHatenaBookmarkItems fetches items from the hotentry RSS of a Hatena Bookmark category
+// and filters them based on the deny-listed domains and the bookmark thresholds of the category
+func (f *Fetcher) FetchHatenaBookmarkItems(category models.HatenaBookmarkCategory) ([]models.LatestItem, error) {
+	return f.FetchHatenaBookmarkItemsWithHistory(category, nil)
+}
+
+// FetchHatenaBookmarkItemsWithHistory fetches items like FetchHatenaBookmarkItems, recording the
+// bookmark counts in history. Items below the threshold are also collected when they satisfy
+// the rising rule of the category according to the history
+func (f *Fetcher) FetchHatenaBookmarkItemsWithHistory(category models.HatenaBookmarkCategory, history *BookmarkHistory) ([]models.LatestItem, error) {
+	var risingWindow time.Duration
+	if category.Rising != nil {
+		window, err := time.ParseDuration(category.Rising.Window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rising window for Hatena Bookmark %s: %w", category.Name, err)
+		}
+		risingWindow = window
+	}
+
+	hatenaURL := fmt.Sprintf("%s/hotentry/%s.rss", f.BaseURLs.HatenaBookmark, category.Name)
+
+	resp, err := f.get(hatenaURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch Hatena Bookmark RSS: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error %d when fetching Hatena Bookmark RSS", resp.StatusCode)
-	}
-
 	var feed models.HatenaBookmarkFeed
-	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
-		return nil, fmt.Errorf("failed to decode Hatena Bookmark RSS feed: %w", err)
-	}
-
-	if len(feed.Items) == 0 {
-		return []models.LatestItem{}, nil
+	decoder, err := newFeedDecoder(resp)
+	if err != nil {
+		return nil, err
 	}
-
-	// Interest sites with lower threshold (matches TypeScript implementation)
-	interestedSites := []string{
-		"https://speakerdeck.com/",
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode Hatena Bookmark RSS feed: %w", err)
 	}
 
-	var filteredItems []models.LatestItem
+	filteredItems := []models.LatestItem{}
 	for _, item := range feed.Items {
-		// Filter out Zenn links to avoid duplication (matches TypeScript implementation)
-		if strings.HasPrefix(item.Link, "https://zenn.dev/") {
+		link := canonical.URL(item.Link)
+		host := linkHost(link)
+
+		// Deny-listed domains are covered by other feeds (e.g. Zenn) and would be duplicates
+		if matchesAnyDomain(host, category.DenyDomains) {
 			continue
 		}
 
-		// Check bookmark count with site-specific thresholds (matches TypeScript implementation)
-		isInterestedSite := false
-		for _, site := range interestedSites {
-			if strings.HasPrefix(item.Link, site) {
-				isInterestedSite = true
-				break
-			}
-		}
+		increase := history.record(link, item.BookmarkCount, risingWindow)
+		rising := category.Rising != nil && increase >= category.Rising.MinIncrease
 
-		// Apply different thresholds based on site (matches TypeScript implementation)
-		if isInterestedSite && item.BookmarkCount > 100 {
-			filteredItems = append(filteredItems, models.LatestItem{
-				Title:    strings.TrimSpace(item.Title),
-				Link:     strings.TrimSpace(item.Link),
-				Category: "hatena-bookmark-tech",
-			})
-		} else if !isInterestedSite && item.BookmarkCount > 120 {
+		if item.BookmarkCount > hatenaBookmarkThreshold(host, category) || rising {
+			if rising {
+				log.Printf("Hatena Bookmark entry rising fast: %s (+%d within %s)", link, increase, risingWindow)
+			}
 			filteredItems = append(filteredItems, models.LatestItem{
-				Title:    strings.TrimSpace(item.Title),
-				Link:     strings.TrimSpace(item.Link),
-				Category: "hatena-bookmark-tech",
+				Title:     strings.TrimSpace(item.Title),
+				Link:      link,
+				Category:  category.Category,
+				Bookmarks: item.BookmarkCount,
+				FeedName:  "Hatena Bookmark",
 			})
 		}
 	}
 
 	return filteredItems, nil
-}
\ No newline at end of file
+}
+
+// hatenaBookmarkThreshold returns the bookmark threshold for a host,
+// preferring the most specific matching domain threshold
+func hatenaBookmarkThreshold(host string, category models.HatenaBookmarkCategory) int {
+	threshold := category.Threshold
+	matched := ""
+	for domain, domainThreshold := range category.DomainThresholds {
+		if domainMatches(host, domain) && len(domain) > len(matched) {
+			threshold = domainThreshold
+			matched = domain
+		}
+	}
+	return threshold
+}
+
+// linkHost returns the lower-cased host of a link ("" if the link cannot be parsed)
+func linkHost(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// domainMatches reports whether the host is the domain or one of its subdomains
+func domainMatches(host string, domain string) bool {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	return domain != "" && (host == domain || strings.HasSuffix(host, "."+domain))
+}
+
+// matchesAnyDomain reports whether the host matches any of the domains
+func matchesAnyDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		if domainMatches(host, domain) {
+			return true
+		}
+	}
+	return false
+}
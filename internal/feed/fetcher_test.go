@@ -1,6 +1,7 @@
 package feed
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -100,30 +101,6 @@ func TestGetFeedURL(t *testing.T) {
 	}
 }
 
-func TestIsAtomFeed(t *testing.T) {
-	tests := []struct {
-		feedType string
-		expected bool
-	}{
-		{"qiita", true},
-		{"connpass", true},
-		{"categoryIsAtomUrl", true},
-		{"zenn", false},
-		{"note", false},
-		{"hatena", false},
-		{"scrapbox", false},
-		{"categoryIsUrl", false},
-		{"unknown", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.feedType, func(t *testing.T) {
-			result := isAtomFeed(tt.feedType)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestParseDate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -360,4 +337,109 @@ func TestFetchLatestItem_EmptyFeed(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, item)
 	assert.Contains(t, err.Error(), "no items found")
-}
\ No newline at end of file
+}
+
+func TestSelectNewItems(t *testing.T) {
+	items := []models.LatestItem{
+		{Title: "Article 4", Link: "https://example.com/4"},
+		{Title: "Article 3", Link: "https://example.com/3"},
+		{Title: "Article 2", Link: "https://example.com/2"},
+		{Title: "Article 1", Link: "https://example.com/1"},
+	}
+
+	tests := []struct {
+		name       string
+		latestLink string
+		maxItems   int
+		expected   []string
+	}{
+		{
+			name:       "all items newer than latest link",
+			latestLink: "https://example.com/1",
+			maxItems:   0,
+			expected:   []string{"https://example.com/4", "https://example.com/3", "https://example.com/2"},
+		},
+		{
+			name:       "latest link unchanged",
+			latestLink: "https://example.com/4",
+			maxItems:   0,
+			expected:   []string{},
+		},
+		{
+			name:       "capped by max items",
+			latestLink: "https://example.com/1",
+			maxItems:   2,
+			expected:   []string{"https://example.com/4", "https://example.com/3"},
+		},
+		{
+			name:       "empty latest link",
+			latestLink: "",
+			maxItems:   0,
+			expected:   []string{"https://example.com/4"},
+		},
+		{
+			name:       "latest link with tracking params",
+			latestLink: "http://example.com/2/?utm_source=rss",
+			maxItems:   0,
+			expected:   []string{"https://example.com/4", "https://example.com/3"},
+		},
+		{
+			name:       "latest link no longer in feed",
+			latestLink: "https://example.com/0",
+			maxItems:   0,
+			expected:   []string{"https://example.com/4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := models.FeedConfig{Name: "Test Feed", LatestLink: tt.latestLink}
+			result := selectNewItems(items, config, tt.maxItems)
+
+			links := []string{}
+			for _, item := range result {
+				links = append(links, item.Link)
+			}
+			assert.Equal(t, tt.expected, links)
+		})
+	}
+
+	// A feed without items (e.g. every GitHub issue filtered out) has no new item
+	assert.Empty(t, selectNewItems(nil, models.FeedConfig{Name: "Test Feed", LatestLink: "https://example.com/1"}, 0))
+	assert.Empty(t, selectNewItems(nil, models.FeedConfig{Name: "Test Feed"}, 0))
+}
+
+func TestParseRSSFeed_Metadata(t *testing.T) {
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <item>
+      <title>With Creator</title>
+      <link>https://example.com/creator</link>
+      <description><![CDATA[<p>First &amp; <em>foremost</em></p>]]></description>
+      <author>editor@example.com (Editor)</author>
+      <dc:creator>Taro</dc:creator>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>With Author</title>
+      <link>https://example.com/author</link>
+      <author>editor@example.com (Editor)</author>
+      <pubDate>Sun, 05 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(rssXML))}
+	items, err := parseRSSFeed(resp, models.FeedConfig{Category: "test"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// dc:creator is preferred over the author element
+	assert.Equal(t, "First & foremost", items[0].Description)
+	assert.Equal(t, "Taro", items[0].Author)
+	assert.Equal(t, time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC), items[0].PublishedAt.UTC())
+
+	assert.Equal(t, "", items[1].Description)
+	assert.Equal(t, "Editor", items[1].Author)
+}
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+// itemFilter keeps the entries of a feed matching its include and exclude patterns
+// A nil *itemFilter keeps every entry
+type itemFilter struct {
+	include []itemPattern
+	exclude []itemPattern
+}
+
+// itemPattern is a case-insensitive keyword or a regular expression written as /pattern/
+type itemPattern struct {
+	keyword string
+	regexp  *regexp.Regexp
+}
+
+// newItemFilter compiles the include and exclude patterns of a feed
+// Returns nil when the feed has no pattern
+func newItemFilter(config models.FeedConfig) (*itemFilter, error) {
+	if len(config.Include) == 0 && len(config.Exclude) == 0 {
+		return nil, nil
+	}
+
+	include, err := compileItemPatterns(config.Include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include pattern for %s: %w", config.Name, err)
+	}
+	exclude, err := compileItemPatterns(config.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern for %s: %w", config.Name, err)
+	}
+	return &itemFilter{include: include, exclude: exclude}, nil
+}
+
+// compileItemPatterns parses keywords and /regexp/ patterns
+func compileItemPatterns(patterns []string) ([]itemPattern, error) {
+	var compiled []itemPattern
+	for _, pattern := range patterns {
+		if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err != nil {
+				return nil, fmt.Errorf("%q: %w", pattern, err)
+			}
+			compiled = append(compiled, itemPattern{regexp: re})
+			continue
+		}
+
+		keyword := strings.ToLower(strings.TrimSpace(pattern))
+		if keyword == "" {
+			continue
+		}
+		compiled = append(compiled, itemPattern{keyword: keyword})
+	}
+	return compiled, nil
+}
+
+// matches reports whether the pattern matches the text
+func (p itemPattern) matches(text string) bool {
+	if p.regexp != nil {
+		return p.regexp.MatchString(text)
+	}
+	return strings.Contains(strings.ToLower(text), p.keyword)
+}
+
+// accepts reports whether the item matches an include pattern (if any) and no exclude pattern
+// Patterns are matched against the title and the description
+func (f *itemFilter) accepts(item models.LatestItem) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchesAnyPattern(item, f.include) {
+		return false
+	}
+	return !matchesAnyPattern(item, f.exclude)
+}
+
+// matchesAnyPattern reports whether any pattern matches the title or the description of the item
+func matchesAnyPattern(item models.LatestItem, patterns []itemPattern) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(item.Title) || (item.Description != "" && pattern.matches(item.Description)) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the items accepted by the filter
+func (f *itemFilter) apply(items []models.LatestItem) []models.LatestItem {
+	if f == nil {
+		return items
+	}
+
+	var accepted []models.LatestItem
+	for _, item := range items {
+		if f.accepts(item) {
+			accepted = append(accepted, item)
+		}
+	}
+	return accepted
+}
@@ -0,0 +1,41 @@
+package feed
+
+import (
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemFilter_Accepts(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		item     models.LatestItem
+		expected bool
+	}{
+		{name: "no pattern", item: models.LatestItem{Title: "Anything"}, expected: true},
+		{name: "keyword in title ignoring case", include: []string{"hono"}, item: models.LatestItem{Title: "Getting started with Hono"}, expected: true},
+		{name: "keyword in description", include: []string{"hono"}, item: models.LatestItem{Title: "Edge APIs", Description: "Built with Hono on Workers"}, expected: true},
+		{name: "no include match", include: []string{"hono"}, item: models.LatestItem{Title: "Next.js tips"}, expected: false},
+		{name: "excluded keyword", include: []string{"hono"}, exclude: []string{"PR"}, item: models.LatestItem{Title: "Hono [PR]"}, expected: false},
+		{name: "regexp", include: []string{`/^Go 1\.\d+/`}, item: models.LatestItem{Title: "Go 1.22 released"}, expected: true},
+		{name: "regexp is case-sensitive", include: []string{`/^go 1\.\d+/`}, item: models.LatestItem{Title: "Go 1.22 released"}, expected: false},
+		{name: "excluded regexp", exclude: []string{`/(?i)もくもく会/`}, item: models.LatestItem{Title: "Go もくもく会 #12"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newItemFilter(models.FeedConfig{Name: "Test", Include: tt.include, Exclude: tt.exclude})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, filter.accepts(tt.item))
+		})
+	}
+}
+
+func TestNewItemFilter_InvalidRegexp(t *testing.T) {
+	_, err := newItemFilter(models.FeedConfig{Name: "Test", Exclude: []string{"/[a-/"}})
+	assert.Error(t, err)
+}
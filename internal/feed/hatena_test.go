@@ -1,10 +1,13 @@
 package feed
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"tech-feed-weekly/pkg/models"
+	"time"
 )
 
 func TestFetchHatenaBookmarkTechCategoryItems(t *testing.T) {
@@ -189,4 +192,253 @@ func TestHatenaBookmarkFiltering(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestFetcher_FetchHatenaBookmarkTechCategoryItems(t *testing.T) {
+	mockRSSResponse := `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns="http://purl.org/rss/1.0/"
+         xmlns:hatena="http://www.hatena.ne.jp/info/xmlns#">
+  <item rdf:about="https://example.com/article1">
+    <title>High Quality Article</title>
+    <link>https://example.com/article1</link>
+    <hatena:bookmarkcount>150</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://speakerdeck.com/presentation1">
+    <title>Speaker Deck Presentation</title>
+    <link>https://speakerdeck.com/presentation1</link>
+    <hatena:bookmarkcount>110</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://zenn.dev/article1">
+    <title>Zenn Article (Should be filtered)</title>
+    <link>https://zenn.dev/article1</link>
+    <hatena:bookmarkcount>200</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://example.com/low-bookmark">
+    <title>Low Bookmark Article</title>
+    <link>https://example.com/low-bookmark</link>
+    <hatena:bookmarkcount>50</hatena:bookmarkcount>
+  </item>
+</rdf:RDF>`
+
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(mockRSSResponse))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.HatenaBookmark = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs})
+
+	items, err := fetcher.FetchHatenaBookmarkTechCategoryItems()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if requestPath != "/hotentry/it.rss" {
+		t.Errorf("Expected request to /hotentry/it.rss, got %s", requestPath)
+	}
+
+	var links []string
+	for _, item := range items {
+		links = append(links, item.Link)
+	}
+	expected := []string{"https://example.com/article1", "https://speakerdeck.com/presentation1"}
+	if strings.Join(links, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected links %v, got %v", expected, links)
+	}
+}
+
+func TestFetcher_FetchHatenaBookmarkItems(t *testing.T) {
+	mockRSSResponse := `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns="http://purl.org/rss/1.0/"
+         xmlns:hatena="http://www.hatena.ne.jp/info/xmlns#">
+  <item rdf:about="https://example.com/popular">
+    <title>Popular Article</title>
+    <link>https://example.com/popular</link>
+    <hatena:bookmarkcount>60</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://example.com/quiet">
+    <title>Quiet Article</title>
+    <link>https://example.com/quiet</link>
+    <hatena:bookmarkcount>50</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://blog.example.org/post">
+    <title>Subdomain Post</title>
+    <link>https://blog.example.org/post</link>
+    <hatena:bookmarkcount>20</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://deep.blog.example.org/post">
+    <title>Deeper Subdomain Post</title>
+    <link>https://deep.blog.example.org/post</link>
+    <hatena:bookmarkcount>20</hatena:bookmarkcount>
+  </item>
+  <item rdf:about="https://ads.example.net/page">
+    <title>Denied Page</title>
+    <link>https://ads.example.net/page</link>
+    <hatena:bookmarkcount>500</hatena:bookmarkcount>
+  </item>
+</rdf:RDF>`
+
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(mockRSSResponse))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.HatenaBookmark = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs})
+
+	items, err := fetcher.FetchHatenaBookmarkItems(models.HatenaBookmarkCategory{
+		Name:      "all",
+		Category:  "hatena-bookmark-all",
+		Threshold: 50,
+		DomainThresholds: map[string]int{
+			"example.org":      10,
+			"deep.example.org": 100,
+			"blog.example.org": 30,
+		},
+		DenyDomains: []string{"example.net"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if requestPath != "/hotentry/all.rss" {
+		t.Errorf("Expected request to /hotentry/all.rss, got %s", requestPath)
+	}
+
+	var links []string
+	for _, item := range items {
+		links = append(links, item.Link)
+		if item.Category != "hatena-bookmark-all" {
+			t.Errorf("Expected category hatena-bookmark-all, got %s", item.Category)
+		}
+	}
+	// blog.example.org uses its own threshold (30), the more general example.org one (10) is ignored
+	expected := []string{"https://example.com/popular"}
+	if strings.Join(links, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected links %v, got %v", expected, links)
+	}
+}
+
+func TestProcessHatenaBookmark(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		switch r.URL.Path {
+		case "/hotentry/it.rss":
+			w.Write([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:hatena="http://www.hatena.ne.jp/info/xmlns#">
+  <item><title>Shared</title><link>https://example.com/shared</link><hatena:bookmarkcount>200</hatena:bookmarkcount></item>
+  <item><title>Existing</title><link>https://example.com/existing</link><hatena:bookmarkcount>200</hatena:bookmarkcount></item>
+</rdf:RDF>`))
+		case "/hotentry/all.rss":
+			w.Write([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:hatena="http://www.hatena.ne.jp/info/xmlns#">
+  <item><title>Shared</title><link>https://example.com/shared</link><hatena:bookmarkcount>200</hatena:bookmarkcount></item>
+  <item><title>General</title><link>https://example.com/general</link><hatena:bookmarkcount>200</hatena:bookmarkcount></item>
+</rdf:RDF>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.HatenaBookmark = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MaxRetries: -1, MinRequestInterval: -1})
+
+	hatenaConfig := &models.HatenaBookmarkConfig{
+		Categories: []models.HatenaBookmarkCategory{
+			{Name: "it", Category: "hatena-bookmark-tech", Threshold: 100},
+			{Name: "missing", Category: "hatena-bookmark-missing", Threshold: 100},
+			{Name: "all", Category: "hatena-bookmark-all", Threshold: 100},
+		},
+	}
+	existingItems := &models.LatestItems{Items: []models.LatestItem{{Link: "https://example.com/existing"}}}
+
+	items, errs := processHatenaBookmark(hatenaConfig, existingItems, fetcher, nil)
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 error for the missing category, got %v", errs)
+	}
+
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Category+" "+item.Link)
+	}
+	expected := []string{
+		"hatena-bookmark-tech https://example.com/shared",
+		"hatena-bookmark-all https://example.com/general",
+	}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected items %v, got %v", expected, got)
+	}
+}
+
+func TestFetcher_FetchHatenaBookmarkItemsWithHistory_Rising(t *testing.T) {
+	counts := map[string]int{"https://example.com/rising": 20, "https://example.com/steady": 90}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:hatena="http://www.hatena.ne.jp/info/xmlns#">`))
+		for _, link := range []string{"https://example.com/rising", "https://example.com/steady"} {
+			w.Write([]byte(fmt.Sprintf(`<item><title>%s</title><link>%s</link><hatena:bookmarkcount>%d</hatena:bookmarkcount></item>`, link, link, counts[link])))
+		}
+		w.Write([]byte(`</rdf:RDF>`))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.HatenaBookmark = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MinRequestInterval: -1})
+
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	history, now := newTestBookmarkHistory(nil, start)
+	category := models.HatenaBookmarkCategory{
+		Name:      "it",
+		Category:  "hatena-bookmark-tech",
+		Threshold: 100,
+		Rising:    &models.HatenaBookmarkRisingRule{MinIncrease: 50, Window: "3h"},
+	}
+
+	items, err := fetcher.FetchHatenaBookmarkItemsWithHistory(category, history)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected no items on the first run, got %v", items)
+	}
+
+	// Two hours later the first entry gained 60 bookmarks, the second one only 5
+	*now = start.Add(2 * time.Hour)
+	counts["https://example.com/rising"] = 80
+	counts["https://example.com/steady"] = 95
+
+	items, err = fetcher.FetchHatenaBookmarkItemsWithHistory(category, history)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 1 || items[0].Link != "https://example.com/rising" {
+		t.Fatalf("Expected only the rising entry, got %v", items)
+	}
+	if items[0].Bookmarks != 80 {
+		t.Errorf("Expected 80 bookmarks, got %d", items[0].Bookmarks)
+	}
+}
+
+func TestFetcher_FetchHatenaBookmarkItemsWithHistory_InvalidWindow(t *testing.T) {
+	category := models.HatenaBookmarkCategory{
+		Name:   "it",
+		Rising: &models.HatenaBookmarkRisingRule{MinIncrease: 50, Window: "three hours"},
+	}
+
+	_, err := defaultFetcher.FetchHatenaBookmarkItemsWithHistory(category, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid rising window") {
+		t.Errorf("Expected invalid rising window error, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package feed
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"tech-feed-weekly/pkg/models"
+	"time"
+)
+
+const (
+	// DefaultQuarantineAfter is the number of consecutive failures after which a feed is skipped
+	DefaultQuarantineAfter = 5
+	// DefaultReprobeInterval is how often a quarantined feed is tried again
+	DefaultReprobeInterval = 24 * time.Hour
+)
+
+// FeedHealth tracks the health of every feed across runs and quarantines feeds that keep failing.
+// A quarantined feed is skipped, except for one attempt per re-probe interval; a successful
+// attempt releases it. A feed that answered 410 Gone is dead and always skipped.
+// Changing the feedUrl of a feed in its config starts its health over (e.g. to revive a dead feed).
+// A nil *FeedHealth tracks nothing and never skips a feed
+type FeedHealth struct {
+	mu              sync.Mutex
+	entries         map[string]models.FeedHealthEntry
+	quarantineAfter int
+	reprobeInterval time.Duration
+	now             func() time.Time
+}
+
+// NewFeedHealth creates a FeedHealth from persisted health data
+// A zero quarantineAfter or reprobeInterval uses the default, a negative quarantineAfter disables quarantine
+func NewFeedHealth(data *models.FeedHealthData, quarantineAfter int, reprobeInterval time.Duration) *FeedHealth {
+	if quarantineAfter == 0 {
+		quarantineAfter = DefaultQuarantineAfter
+	}
+	if reprobeInterval <= 0 {
+		reprobeInterval = DefaultReprobeInterval
+	}
+
+	entries := make(map[string]models.FeedHealthEntry)
+	if data != nil {
+		for key, entry := range data.Feeds {
+			entries[key] = entry
+		}
+	}
+	return &FeedHealth{
+		entries:         entries,
+		quarantineAfter: quarantineAfter,
+		reprobeInterval: reprobeInterval,
+		now:             time.Now,
+	}
+}
+
+// FeedHealthKey returns the key of a feed in the health data
+func FeedHealthKey(category string, name string) string {
+	return category + "/" + name
+}
+
+// Data returns a snapshot of the health data for persisting
+func (h *FeedHealth) Data() *models.FeedHealthData {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	feeds := make(map[string]models.FeedHealthEntry, len(h.entries))
+	for key, entry := range h.entries {
+		feeds[key] = entry
+	}
+	return &models.FeedHealthData{Feeds: feeds}
+}
+
+// Quarantined returns the quarantined feeds sorted by key
+func (h *FeedHealth) Quarantined() []models.FeedHealthEntry {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var keys []string
+	for key, entry := range h.entries {
+		if entry.Quarantined {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var quarantined []models.FeedHealthEntry
+	for _, key := range keys {
+		quarantined = append(quarantined, h.entries[key])
+	}
+	return quarantined
+}
+
+// shouldSkip reports whether the feed is dead, or quarantined and not due for a re-probe
+// A feed whose feedUrl changed since its health was recorded is never skipped
+func (h *FeedHealth) shouldSkip(category string, name string, feedURL string) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.entries[FeedHealthKey(category, name)]
+	if !ok || !entry.Quarantined || entry.FeedURL != feedURL {
+		return false
+	}
+	if entry.Dead {
+		return true
+	}
+	return h.now().Sub(entry.LastChecked) < h.reprobeInterval
+}
+
+// record updates the health of a feed fetched from feedURL with the result of processing it
+func (h *FeedHealth) record(category string, name string, feedURL string, result *ProcessResult) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	key := FeedHealthKey(category, name)
+	entry := h.entries[key]
+	if entry.FeedURL != "" && entry.FeedURL != feedURL && (entry.Quarantined || entry.ConsecutiveFailures > 0) {
+		log.Printf("Resetting the failures of %s: feedUrl changed from %s to %s", name, entry.FeedURL, feedURL)
+		entry.ConsecutiveFailures = 0
+		entry.Quarantined = false
+		entry.Dead = false
+	}
+	entry.FeedURL = feedURL
+	entry.Name = name
+	entry.Category = category
+	if entry.FirstChecked.IsZero() {
+		entry.FirstChecked = now
+	}
+	entry.LastChecked = now
+
+	if result.Error != nil {
+		entry.LastError = result.Error.Error()
+		entry.LastErrorAt = now
+		entry.ConsecutiveFailures++
+		if errors.Is(result.Error, ErrGone) {
+			log.Printf("Marking %s as dead: the feed is gone", name)
+			entry.Dead = true
+			entry.Quarantined = true
+		}
+		if h.quarantineAfter > 0 && entry.ConsecutiveFailures >= h.quarantineAfter && !entry.Quarantined {
+			log.Printf("Quarantining %s after %d consecutive failures, retrying every %s", name, entry.ConsecutiveFailures, h.reprobeInterval)
+			entry.Quarantined = true
+		}
+	} else {
+		if entry.Quarantined {
+			log.Printf("Releasing %s from quarantine: the feed works again", name)
+		}
+		entry.LastSuccess = now
+		entry.ConsecutiveFailures = 0
+		entry.Quarantined = false
+		if len(result.NewItems) > 0 {
+			entry.LastNewItem = now
+			entry.TotalItems += len(result.NewItems)
+		}
+	}
+
+	h.entries[key] = entry
+}
+
+// retain drops the health of feeds that are no longer configured
+func (h *FeedHealth) retain(keys map[string]bool) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key := range h.entries {
+		if !keys[key] {
+			delete(h.entries, key)
+		}
+	}
+}
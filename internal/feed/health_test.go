@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFeedHealth creates a FeedHealth whose clock is controlled by the returned pointer
+func newTestFeedHealth(data *models.FeedHealthData, quarantineAfter int, start time.Time) (*FeedHealth, *time.Time) {
+	now := start
+	health := NewFeedHealth(data, quarantineAfter, 24*time.Hour)
+	health.now = func() time.Time { return now }
+	return health, &now
+}
+
+func TestFeedHealth_Record(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	health, now := newTestFeedHealth(nil, 3, start)
+
+	health.record("company", "Blog", "https://example.com/feed", &ProcessResult{NewItems: []models.LatestItem{{Title: "a"}, {Title: "b"}}})
+	*now = start.Add(time.Hour)
+	health.record("company", "Blog", "https://example.com/feed", &ProcessResult{Error: errors.New("HTTP error 500")})
+
+	assert.Equal(t, models.FeedHealthEntry{
+		Name:                "Blog",
+		Category:            "company",
+		FeedURL:             "https://example.com/feed",
+		FirstChecked:        start,
+		LastChecked:         start.Add(time.Hour),
+		LastSuccess:         start,
+		LastError:           "HTTP error 500",
+		LastErrorAt:         start.Add(time.Hour),
+		ConsecutiveFailures: 1,
+		LastNewItem:         start,
+		TotalItems:          2,
+	}, health.Data().Feeds["company/Blog"])
+}
+
+func TestFeedHealth_Quarantine(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	health, now := newTestFeedHealth(nil, 3, start)
+	failure := &ProcessResult{Error: errors.New("HTTP error 404")}
+
+	for i := 0; i < 2; i++ {
+		health.record("company", "Moved Blog", "https://example.com/feed", failure)
+		assert.False(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+	}
+	health.record("company", "Moved Blog", "https://example.com/feed", failure)
+	assert.True(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+	require.Len(t, health.Quarantined(), 1)
+	assert.Equal(t, "Moved Blog", health.Quarantined()[0].Name)
+
+	// Re-probed once the interval has passed; another failure keeps the feed quarantined
+	*now = start.Add(23 * time.Hour)
+	assert.True(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+	*now = start.Add(24 * time.Hour)
+	assert.False(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+	health.record("company", "Moved Blog", "https://example.com/feed", failure)
+	assert.True(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+
+	// A successful re-probe releases the feed
+	*now = start.Add(48 * time.Hour)
+	health.record("company", "Moved Blog", "https://example.com/feed", &ProcessResult{})
+	assert.False(t, health.shouldSkip("company", "Moved Blog", "https://example.com/feed"))
+	assert.Empty(t, health.Quarantined())
+	assert.Equal(t, 0, health.Data().Feeds["company/Moved Blog"].ConsecutiveFailures)
+}
+
+func TestFeedHealth_QuarantineDisabled(t *testing.T) {
+	health, _ := newTestFeedHealth(nil, -1, time.Now())
+	for i := 0; i < 10; i++ {
+		health.record("company", "Blog", "https://example.com/feed", &ProcessResult{Error: errors.New("timeout")})
+	}
+	assert.False(t, health.shouldSkip("company", "Blog", "https://example.com/feed"))
+}
+
+func TestFeedHealth_Gone(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	health, now := newTestFeedHealth(nil, 3, start)
+
+	health.record("company", "Closed Blog", "https://example.com/feed", &ProcessResult{Error: fmt.Errorf("failed to fetch latest item for Closed Blog: %w", ErrGone)})
+	entry := health.Data().Feeds["company/Closed Blog"]
+	assert.True(t, entry.Dead)
+	assert.True(t, entry.Quarantined)
+
+	// A dead feed is never re-probed
+	*now = start.Add(30 * 24 * time.Hour)
+	assert.True(t, health.shouldSkip("company", "Closed Blog", "https://example.com/feed"))
+}
+
+func TestFeedHealth_FeedURLChanged(t *testing.T) {
+	start := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	health, now := newTestFeedHealth(nil, 3, start)
+
+	health.record("company", "Closed Blog", "https://old.example.com/feed", &ProcessResult{Error: ErrGone})
+	assert.True(t, health.shouldSkip("company", "Closed Blog", "https://old.example.com/feed"))
+
+	// Fixing the feedUrl in the config fetches the feed again, and the dead state is cleared
+	assert.False(t, health.shouldSkip("company", "Closed Blog", "https://new.example.com/feed"))
+	*now = start.Add(time.Hour)
+	health.record("company", "Closed Blog", "https://new.example.com/feed", &ProcessResult{Error: errors.New("HTTP error 500")})
+	entry := health.Data().Feeds["company/Closed Blog"]
+	assert.False(t, entry.Dead)
+	assert.False(t, entry.Quarantined)
+	assert.Equal(t, 1, entry.ConsecutiveFailures)
+	assert.Equal(t, "https://new.example.com/feed", entry.FeedURL)
+}
+
+func TestFeedHealth_Nil(t *testing.T) {
+	var health *FeedHealth
+	health.record("company", "Blog", "https://example.com/feed", &ProcessResult{})
+	assert.False(t, health.shouldSkip("company", "Blog", "https://example.com/feed"))
+	assert.Empty(t, health.Quarantined())
+}
+
+func TestProcessAllFeeds_SkipsQuarantinedFeeds(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	configMap := map[string]*config.ConfigFileData{
+		"company": {
+			FilePath: filepath.Join(t.TempDir(), "company.json"),
+			Category: "company",
+			Data: []models.FeedConfig{
+				{Name: "Broken", Type: "categoryIsUrl", FeedURL: server.URL, Category: "company"},
+			},
+		},
+	}
+	data := &models.FeedHealthData{Feeds: map[string]models.FeedHealthEntry{
+		"company/Removed": {Name: "Removed", Category: "company"},
+	}}
+	health, _ := newTestFeedHealth(data, 2, time.Now())
+	options := ProcessOptions{Fetcher: NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1}), Health: health}
+
+	for i := 0; i < 3; i++ {
+		ProcessAllFeedsWithOptions(configMap, &models.LatestItems{}, options)
+	}
+
+	// The third run skips the feed quarantined after two failures
+	assert.Equal(t, 2, requests)
+	entry := health.Data().Feeds["company/Broken"]
+	assert.True(t, entry.Quarantined)
+	assert.Contains(t, entry.LastError, "HTTP error 404")
+
+	// Feeds that are no longer configured are dropped
+	assert.NotContains(t, health.Data().Feeds, "company/Removed")
+}
@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"tech-feed-weekly/pkg/models"
+)
+
+// feedJob represents a single feed configuration to be processed by the worker pool
+type feedJob struct {
+	categoryName string
+	feedConfig   *models.FeedConfig
+}
+
+// processFeedJobs processes feed jobs concurrently and returns the results in job order.
+// Each job updates only its own FeedConfig, so the configs can be shared between workers safely
+func processFeedJobs(jobs []feedJob, existingItems *models.LatestItems, options ProcessOptions) []*ProcessResult {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	results := make([]*ProcessResult, len(jobs))
+	limiter := newHostLimiter(options.MaxPerHost)
+	jobIndexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobIndexes {
+				feedConfig := jobs[i].feedConfig
+				feedURL := feedConfig.FeedURL
+				if options.Health.shouldSkip(jobs[i].categoryName, feedConfig.Name, feedURL) {
+					log.Printf("Skipping quarantined feed: %s", feedConfig.Name)
+					results[i] = &ProcessResult{Skipped: true}
+					continue
+				}
+				log.Printf("Processing feed: %s", feedConfig.Name)
+
+				release := limiter.acquire(options.Fetcher.feedHost(*feedConfig))
+				results[i] = processFeedConfig(feedConfig, existingItems, options)
+				release()
+				options.Health.record(jobs[i].categoryName, feedConfig.Name, feedURL, results[i])
+			}
+		}()
+	}
+
+	for i := range jobs {
+		jobIndexes <- i
+	}
+	close(jobIndexes)
+	wg.Wait()
+
+	return results
+}
+
+// hostLimiter limits the number of concurrent requests per host
+type hostLimiter struct {
+	mu         sync.Mutex
+	maxPerHost int
+	semaphores map[string]chan struct{}
+}
+
+// newHostLimiter creates a hostLimiter allowing maxPerHost concurrent requests per host
+func newHostLimiter(maxPerHost int) *hostLimiter {
+	if maxPerHost <= 0 {
+		maxPerHost = DefaultMaxPerHost
+	}
+	return &hostLimiter{
+		maxPerHost: maxPerHost,
+		semaphores: make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a request slot for the host is available and returns its release function
+func (l *hostLimiter) acquire(host string) func() {
+	l.mu.Lock()
+	semaphore, ok := l.semaphores[host]
+	if !ok {
+		semaphore = make(chan struct{}, l.maxPerHost)
+		l.semaphores[host] = semaphore
+	}
+	l.mu.Unlock()
+
+	semaphore <- struct{}{}
+	return func() { <-semaphore }
+}
+
+// feedHost returns the host the feed is fetched from
+func (f *Fetcher) feedHost(feedConfig models.FeedConfig) string {
+	parsedURL, err := url.Parse(f.feedURL(feedConfig))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsedURL.Host)
+}
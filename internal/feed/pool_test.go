@@ -0,0 +1,136 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessAllFeeds_ConcurrentDeterministicOrder(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var current, maxConcurrent int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			m := atomic.LoadInt32(&maxConcurrent)
+			if n <= m || atomic.CompareAndSwapInt32(&maxConcurrent, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		name := r.URL.Query().Get("feed")
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>%s</title>
+      <link>https://example.com/%s</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`, name, name)
+	}))
+	defer server.Close()
+
+	configMap := map[string]*config.ConfigFileData{}
+	for _, category := range []string{"b", "a"} {
+		configData := &config.ConfigFileData{
+			FilePath: filepath.Join(tempDir, category+".json"),
+			Category: category,
+		}
+		for i := 0; i < 4; i++ {
+			name := fmt.Sprintf("%s%d", category, i)
+			configData.Data = append(configData.Data, models.FeedConfig{
+				Name:       name,
+				Type:       "categoryIsUrl",
+				FeedURL:    server.URL + "?feed=" + name,
+				LatestLink: "https://example.com/old",
+				Category:   category,
+			})
+		}
+		configMap[category] = configData
+	}
+
+	existingItems := &models.LatestItems{Items: []models.LatestItem{}}
+
+	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, ProcessOptions{
+		Workers:    4,
+		MaxPerHost: 2,
+		Fetcher:    NewFetcher(FetcherConfig{MinRequestInterval: -1}),
+	})
+	require.NoError(t, err)
+
+	var titles []string
+	for _, item := range newItems {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"a0", "a1", "a2", "a3", "b0", "b1", "b2", "b3"}, titles)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxConcurrent), int32(2))
+
+	for _, category := range []string{"a", "b"} {
+		for i, feedConfig := range configMap[category].Data {
+			assert.Equal(t, fmt.Sprintf("https://example.com/%s%d", category, i), feedConfig.LatestLink)
+		}
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	limiter := newHostLimiter(1)
+
+	var wg sync.WaitGroup
+	var current, maxConcurrent int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := limiter.acquire("example.com")
+			defer release()
+
+			n := atomic.AddInt32(&current, 1)
+			if n > atomic.LoadInt32(&maxConcurrent) {
+				atomic.StoreInt32(&maxConcurrent, n)
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxConcurrent)
+
+	// A different host has its own slots
+	release := limiter.acquire("example.com")
+	done := make(chan struct{})
+	go func() {
+		limiter.acquire("other.example.com")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected other host not to be blocked")
+	}
+	release()
+}
+
+func TestFeedHost(t *testing.T) {
+	assert.Equal(t, "zenn.dev", defaultFetcher.feedHost(models.FeedConfig{Type: "zenn", FeedURL: "user"}))
+	assert.Equal(t, "api.github.com", defaultFetcher.feedHost(models.FeedConfig{Type: "github-issues", FeedURL: "honojs/hono"}))
+	assert.Equal(t, "example.com", defaultFetcher.feedHost(models.FeedConfig{Type: "categoryIsUrl", FeedURL: "https://Example.com/feed"}))
+	assert.Equal(t, "", defaultFetcher.feedHost(models.FeedConfig{Type: "unknown"}))
+}
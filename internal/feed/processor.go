@@ -1,118 +1,355 @@
 package feed
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"tech-feed-weekly/internal/canonical"
 	"tech-feed-weekly/internal/config"
 	"tech-feed-weekly/pkg/models"
+	"time"
 )
 
+// DefaultWorkers is the number of feeds processed concurrently when not specified
+const DefaultWorkers = 8
+
+// DefaultMaxPerHost is the number of concurrent requests to a single host when not specified
+const DefaultMaxPerHost = 2
+
+// DefaultMaxAge is the age beyond which entries are ignored by the collector (30 days)
+const DefaultMaxAge = 30 * 24 * time.Hour
+
+// ProcessOptions represents options for processing all feeds
+type ProcessOptions struct {
+	HatenaBookmark *models.HatenaBookmarkConfig // Hatena Bookmark categories to aggregate (nil disables it)
+	HatenaHistory  *BookmarkHistory             // Bookmark counts across runs for rising rules (nil disables tracking)
+	Workers        int                          // Number of feeds processed concurrently (0 means DefaultWorkers)
+	MaxPerHost     int                          // Max concurrent requests per host (0 means DefaultMaxPerHost)
+	MaxAge         time.Duration                // Entries older than this are ignored unless the feed sets maxAge (0 disables the cutoff)
+	Health         *FeedHealth                  // Feed health across runs used to skip failing feeds (nil disables tracking)
+	KeepFeedURLs   bool                         // Do not rewrite the feedUrl of permanently redirected feeds
+
+	Fetcher *Fetcher // Fetcher used for all requests (nil means the default fetcher)
+}
+
+// DefaultProcessOptions returns the options used by ProcessAllFeeds
+func DefaultProcessOptions() ProcessOptions {
+	return ProcessOptions{
+		HatenaBookmark: DefaultHatenaBookmarkConfig(),
+		Workers:        DefaultWorkers,
+		MaxPerHost:     DefaultMaxPerHost,
+	}
+}
+
 // ProcessResult represents the result of processing a feed
 type ProcessResult struct {
-	NewItem       *models.LatestItem
+	NewItems      []models.LatestItem
 	ConfigUpdated bool
 	Error         error
+	UnparsedDates []string // Dates in the feed that could not be parsed
+	Skipped       bool     // The feed is quarantined and was not fetched
 }
 
-// ProcessFeedConfig processes a single feed configuration and returns the latest item if it's new
-// Also updates the config if a new item is found
+// ProcessFeedConfig processes a single feed configuration and returns the items that are new
+// since the recorded latestLink. Also updates the config if new items are found
 func ProcessFeedConfig(config *models.FeedConfig, existingItems *models.LatestItems) *ProcessResult {
+	return processFeedConfig(config, existingItems, ProcessOptions{Fetcher: defaultFetcher})
+}
+
+// processFeedConfig processes a single feed configuration with the fetcher of the options
+// A 304 Not Modified response is treated as no new item. Entries older than the max age
+// (the feed's maxAge, or options.MaxAge when the feed has none) and entries rejected by the
+// feed's include/exclude patterns are ignored; when no entry is left the latestLink is only
+// moved to the newest entry. The feedUrl of a feed that permanently
+// redirects is rewritten unless options.KeepFeedURLs is set
+func processFeedConfig(config *models.FeedConfig, existingItems *models.LatestItems, options ProcessOptions) *ProcessResult {
 	result := &ProcessResult{}
+	fetcher := options.Fetcher
+
+	maxAge, err := feedMaxAge(*config, options.MaxAge)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	filter, err := newItemFilter(*config)
+	if err != nil {
+		result.Error = err
+		return result
+	}
 
-	// Fetch the latest item from the feed
-	latestItem, err := FetchLatestItem(*config)
+	// Fetch the items newer than the recorded latestLink
+	items, err := fetcher.FetchItems(*config)
+	if err == nil || errors.Is(err, ErrNotModified) {
+		updateMovedFeedURL(config, fetcher, options.KeepFeedURLs, result)
+	}
+	if errors.Is(err, ErrNotModified) {
+		log.Printf("No new item for %s: feed not modified", config.Name)
+		return result // No new item
+	}
 	if err != nil {
 		result.Error = fmt.Errorf("failed to fetch latest item for %s: %w", config.Name, err)
 		return result
 	}
+	for _, item := range items {
+		if item.UnparsedDate != "" {
+			result.UnparsedDates = append(result.UnparsedDates, item.UnparsedDate)
+		}
+	}
+	if len(result.UnparsedDates) > 0 {
+		log.Printf("Could not parse %d dates in %s, ranking those entries by document order", len(result.UnparsedDates), config.Name)
+	}
+
+	candidates := selectNewItems(items, *config, config.MaxItems)
 
-	// Check if the latest item URL is different from the recorded latestLink
-	if latestItem.Link == config.LatestLink {
+	if len(candidates) == 0 {
 		log.Printf("No new item for %s: latest link unchanged", config.Name)
 		return result // No new item
 	}
 
-	// Check if this item already exists in the existing items
-	for _, item := range existingItems.Items {
-		if item.Link == latestItem.Link {
-			log.Printf("Item already exists for %s: %s", config.Name, latestItem.Link)
-			return result // Item already exists
+	recent := recentItems(candidates, maxAge, time.Now())
+	if len(recent) < len(candidates) {
+		log.Printf("Ignoring %d entries older than %s for %s", len(candidates)-len(recent), maxAge, config.Name)
+	}
+	accepted := filter.apply(recent)
+	filtered := len(recent) - len(accepted)
+	if filtered > 0 {
+		log.Printf("Filtered out %d entries of %s by include/exclude patterns", filtered, config.Name)
+	}
+	if len(accepted) == 0 {
+		// A new feed, one republishing old entries or only filtered entries: remember where it is without emitting anything
+		if config.LatestLink != candidates[0].Link {
+			log.Printf("Seeding latest link for %s: %s", config.Name, candidates[0].Link)
+			config.LatestLink = candidates[0].Link
+			result.ConfigUpdated = true
 		}
+		return result
 	}
 
-	log.Printf("New item found for %s: %s", config.Name, latestItem.Title)
+	for _, candidate := range accepted {
+		// Check if this item already exists in the existing items
+		if itemExists(existingItems, candidate.Link) {
+			log.Printf("Item already exists for %s: %s", config.Name, candidate.Link)
+			continue
+		}
 
-	// Update the config's LatestLink
-	config.LatestLink = latestItem.Link
-	result.NewItem = latestItem
+		log.Printf("New item found for %s: %s", config.Name, candidate.Title)
+		result.NewItems = append(result.NewItems, candidate)
+	}
+
+	if len(result.NewItems) == 0 && filtered == 0 {
+		return result // All items already exist
+	}
+
+	// Update the config's LatestLink to the newest item in the feed
+	config.LatestLink = candidates[0].Link
 	result.ConfigUpdated = true
 
 	return result
 }
 
+// updateMovedFeedURL rewrites the feedUrl of a feed that permanently redirected to a new URL
+// Only feeds configured with their URL (e.g. categoryIsUrl) can be rewritten
+func updateMovedFeedURL(config *models.FeedConfig, fetcher *Fetcher, keepFeedURL bool, result *ProcessResult) {
+	feedURL := fetcher.feedURL(*config)
+	movedTo := fetcher.moved.take(feedURL)
+	if movedTo == "" {
+		return
+	}
+
+	if keepFeedURL || feedURL != config.FeedURL {
+		log.Printf("Feed %s moved permanently: %s -> %s (feedUrl not rewritten)", config.Name, feedURL, movedTo)
+		return
+	}
+
+	log.Printf("Feed %s moved permanently, rewriting feedUrl: %s -> %s", config.Name, config.FeedURL, movedTo)
+	config.FeedURL = movedTo
+	result.ConfigUpdated = true
+}
+
+// feedMaxAge returns the max age of a feed, parsed from its maxAge setting
+// or the given default when the feed does not set one
+func feedMaxAge(config models.FeedConfig, defaultMaxAge time.Duration) (time.Duration, error) {
+	if config.MaxAge == "" {
+		return defaultMaxAge, nil
+	}
+	if config.MaxAge == "0" {
+		return 0, nil
+	}
+	maxAge, err := time.ParseDuration(config.MaxAge)
+	if err != nil || maxAge < 0 {
+		return 0, fmt.Errorf("invalid maxAge %q for %s", config.MaxAge, config.Name)
+	}
+	return maxAge, nil
+}
+
+// recentItems returns the items published within maxAge before now
+// Undated items are kept since their age is unknown. A zero maxAge keeps every item
+func recentItems(items []models.LatestItem, maxAge time.Duration, now time.Time) []models.LatestItem {
+	if maxAge <= 0 {
+		return items
+	}
+
+	cutoff := now.Add(-maxAge)
+	var recent []models.LatestItem
+	for _, item := range items {
+		if item.PublishedAt.IsZero() || !item.PublishedAt.Before(cutoff) {
+			recent = append(recent, item)
+		}
+	}
+	return recent
+}
+
+// itemExists checks whether an item with the given link is already in the existing items
+func itemExists(existingItems *models.LatestItems, link string) bool {
+	for _, item := range existingItems.Items {
+		if canonical.Equal(item.Link, link) {
+			return true
+		}
+	}
+	return false
+}
+
 // ProcessAllFeeds processes all feed configurations and returns new items
 // Also updates config files when new items are found
 func ProcessAllFeeds(configMap map[string]*config.ConfigFileData, existingItems *models.LatestItems) ([]models.LatestItem, error) {
-	return ProcessAllFeedsWithOptions(configMap, existingItems, true)
+	return ProcessAllFeedsWithOptions(configMap, existingItems, DefaultProcessOptions())
 }
 
-// ProcessAllFeedsWithOptions processes all feed configurations with configurable options
-func ProcessAllFeedsWithOptions(configMap map[string]*config.ConfigFileData, existingItems *models.LatestItems, enableHatenaBookmark bool) ([]models.LatestItem, error) {
+// DefaultHatenaBookmarkConfig returns the Hatena Bookmark settings used when no config file is given
+// (the tech category only)
+func DefaultHatenaBookmarkConfig() *models.HatenaBookmarkConfig {
+	return &models.HatenaBookmarkConfig{
+		Categories: []models.HatenaBookmarkCategory{techHatenaBookmarkCategory},
+	}
+}
+
+// processHatenaBookmark collects the new items of every configured Hatena Bookmark category.
+// Items already collected (or found in an earlier category) are skipped
+func processHatenaBookmark(hatenaConfig *models.HatenaBookmarkConfig, existingItems *models.LatestItems, fetcher *Fetcher, history *BookmarkHistory) ([]models.LatestItem, []error) {
 	var newItems []models.LatestItem
-	var errors []error
-	updatedConfigs := make(map[string]bool)
+	var errs []error
+	seen := make(map[string]bool)
 
-	// Process Hatena Bookmark tech category (if enabled)
-	if enableHatenaBookmark {
-		log.Println("Processing Hatena Bookmark tech category...")
-		hatenaItems, err := FetchHatenaBookmarkTechCategoryItems()
+	for _, category := range hatenaConfig.Categories {
+		log.Printf("Processing Hatena Bookmark category: %s", category.Name)
+		hatenaItems, err := fetcher.FetchHatenaBookmarkItemsWithHistory(category, history)
 		if err != nil {
-			log.Printf("Error processing Hatena Bookmark: %v", err)
-			errors = append(errors, err)
-		} else {
-			// Filter out items that already exist in latest-items.json
-			for _, hatenaItem := range hatenaItems {
-				itemExists := false
-				for _, existingItem := range existingItems.Items {
-					if existingItem.Link == hatenaItem.Link {
-						itemExists = true
-						break
-					}
-				}
-				if !itemExists {
-					newItems = append(newItems, hatenaItem)
-					log.Printf("New Hatena Bookmark item found: %s", hatenaItem.Title)
-				}
+			log.Printf("Error processing Hatena Bookmark %s: %v", category.Name, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		// Filter out items that already exist in latest-items.json
+		for _, hatenaItem := range hatenaItems {
+			if seen[hatenaItem.Link] || itemExists(existingItems, hatenaItem.Link) {
+				continue
 			}
+			seen[hatenaItem.Link] = true
+			newItems = append(newItems, hatenaItem)
+			log.Printf("New Hatena Bookmark item found: %s", hatenaItem.Title)
 		}
 	}
 
-	for categoryName, configData := range configMap {
-		log.Printf("Processing category: %s", categoryName)
+	return newItems, errs
+}
+
+// feedDateFailures represents the dates of a feed that could not be parsed in a run
+type feedDateFailures struct {
+	feedName string
+	dates    []string
+}
+
+// logDateFailures logs a run summary of the feeds whose dates could not be parsed
+func logDateFailures(failures []feedDateFailures) {
+	if len(failures) == 0 {
+		return
+	}
+	log.Printf("Date parse failures in %d feeds:", len(failures))
+	for _, failure := range failures {
+		log.Printf("  %s: %d entries (e.g. %q)", failure.feedName, len(failure.dates), failure.dates[0])
+	}
+}
+
+// logQuarantinedFeeds logs a run summary of the feeds skipped because they keep failing
+func logQuarantinedFeeds(quarantined []models.FeedHealthEntry) {
+	if len(quarantined) == 0 {
+		return
+	}
+	log.Printf("Quarantined feeds (%d):", len(quarantined))
+	for _, entry := range quarantined {
+		log.Printf("  %s (%s): %d consecutive failures, last error: %s", entry.Name, entry.Category, entry.ConsecutiveFailures, entry.LastError)
+	}
+}
+
+// ProcessAllFeedsWithOptions processes all feed configurations with configurable options
+// Feeds are fetched concurrently, but new items are returned in a deterministic order
+// (Hatena Bookmark first, then categories sorted by name and feeds in config file order)
+func ProcessAllFeedsWithOptions(configMap map[string]*config.ConfigFileData, existingItems *models.LatestItems, options ProcessOptions) ([]models.LatestItem, error) {
+	var newItems []models.LatestItem
+	var errors []error
+	updatedConfigs := make(map[string]bool)
+
+	if options.Fetcher == nil {
+		options.Fetcher = defaultFetcher
+	}
+
+	// Process Hatena Bookmark categories (if configured)
+	if options.HatenaBookmark != nil {
+		hatenaItems, hatenaErrors := processHatenaBookmark(options.HatenaBookmark, existingItems, options.Fetcher, options.HatenaHistory)
+		newItems = append(newItems, hatenaItems...)
+		errors = append(errors, hatenaErrors...)
+	}
+
+	// Sort categories so that the output order does not depend on map iteration
+	var categoryNames []string
+	for categoryName := range configMap {
+		categoryNames = append(categoryNames, categoryName)
+	}
+	sort.Strings(categoryNames)
+
+	var jobs []feedJob
+	configuredFeeds := make(map[string]bool)
+	for _, categoryName := range categoryNames {
+		configData := configMap[categoryName]
+		log.Printf("Processing category: %s (%d feeds)", categoryName, len(configData.Data))
 
 		for i := range configData.Data {
-			feedConfig := &configData.Data[i]
-			log.Printf("Processing feed: %s", feedConfig.Name)
+			jobs = append(jobs, feedJob{
+				categoryName: categoryName,
+				feedConfig:   &configData.Data[i],
+			})
+			configuredFeeds[FeedHealthKey(categoryName, configData.Data[i].Name)] = true
+		}
+	}
+	options.Health.retain(configuredFeeds)
 
-			result := ProcessFeedConfig(feedConfig, existingItems)
-			if result.Error != nil {
-				log.Printf("Error processing %s: %v", feedConfig.Name, result.Error)
-				errors = append(errors, result.Error)
-				continue
-			}
+	results := processFeedJobs(jobs, existingItems, options)
 
-			if result.NewItem != nil {
-				newItems = append(newItems, *result.NewItem)
-			}
+	var dateFailures []feedDateFailures
+	for i, result := range results {
+		job := jobs[i]
+		if len(result.UnparsedDates) > 0 {
+			dateFailures = append(dateFailures, feedDateFailures{feedName: job.feedConfig.Name, dates: result.UnparsedDates})
+		}
+		if result.Error != nil {
+			log.Printf("Error processing %s: %v", job.feedConfig.Name, result.Error)
+			errors = append(errors, result.Error)
+			continue
+		}
 
-			if result.ConfigUpdated {
-				updatedConfigs[categoryName] = true
-			}
+		newItems = append(newItems, result.NewItems...)
+
+		if result.ConfigUpdated {
+			updatedConfigs[job.categoryName] = true
 		}
 	}
 
 	// Update config files that had changes
-	for categoryName := range updatedConfigs {
+	for _, categoryName := range categoryNames {
+		if !updatedConfigs[categoryName] {
+			continue
+		}
 		if err := config.UpdateConfigFile(configMap[categoryName]); err != nil {
 			log.Printf("Error updating config file for %s: %v", categoryName, err)
 			errors = append(errors, fmt.Errorf("failed to update config file for %s: %w", categoryName, err))
@@ -121,6 +358,9 @@ func ProcessAllFeedsWithOptions(configMap map[string]*config.ConfigFileData, exi
 		}
 	}
 
+	logDateFailures(dateFailures)
+	logQuarantinedFeeds(options.Health.Quarantined())
+
 	if len(errors) > 0 {
 		log.Printf("Encountered %d errors during processing", len(errors))
 		// Return the first error for simplicity, but log all errors
@@ -128,4 +368,4 @@ func ProcessAllFeedsWithOptions(configMap map[string]*config.ConfigFileData, exi
 	}
 
 	return newItems, nil
-}
\ No newline at end of file
+}
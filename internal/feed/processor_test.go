@@ -8,6 +8,7 @@ import (
 	"testing"
 	"tech-feed-weekly/internal/config"
 	"tech-feed-weekly/pkg/models"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -52,10 +53,10 @@ func TestProcessFeedConfig_NewItem(t *testing.T) {
 	require.NotNil(t, result)
 	assert.NoError(t, result.Error)
 	assert.True(t, result.ConfigUpdated)
-	assert.NotNil(t, result.NewItem)
-	assert.Equal(t, "New Article", result.NewItem.Title)
-	assert.Equal(t, "https://example.com/new-article", result.NewItem.Link)
-	assert.Equal(t, "test", result.NewItem.Category)
+	require.Len(t, result.NewItems, 1)
+	assert.Equal(t, "New Article", result.NewItems[0].Title)
+	assert.Equal(t, "https://example.com/new-article", result.NewItems[0].Link)
+	assert.Equal(t, "test", result.NewItems[0].Category)
 
 	// Verify config was updated
 	assert.Equal(t, "https://example.com/new-article", feedConfig.LatestLink)
@@ -100,7 +101,7 @@ func TestProcessFeedConfig_NoChange(t *testing.T) {
 	require.NotNil(t, result)
 	assert.NoError(t, result.Error)
 	assert.False(t, result.ConfigUpdated)
-	assert.Nil(t, result.NewItem)
+	assert.Empty(t, result.NewItems)
 }
 
 func TestProcessFeedConfig_ItemAlreadyExists(t *testing.T) {
@@ -149,7 +150,168 @@ func TestProcessFeedConfig_ItemAlreadyExists(t *testing.T) {
 	require.NotNil(t, result)
 	assert.NoError(t, result.Error)
 	assert.False(t, result.ConfigUpdated)
-	assert.Nil(t, result.NewItem)
+	assert.Empty(t, result.NewItems)
+}
+
+func TestProcessFeedConfig_MultipleNewItems(t *testing.T) {
+	// Mock RSS XML response with three posts published since the last run
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Third Article</title>
+      <link>https://example.com/third</link>
+      <pubDate>Wed, 08 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Second Article</title>
+      <link>https://example.com/second</link>
+      <pubDate>Tue, 07 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>First Article</title>
+      <link>https://example.com/first</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Old Article</title>
+      <link>https://example.com/old-article</link>
+      <pubDate>Sun, 05 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Test Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/old-article",
+		Category:   "test",
+	}
+
+	// The second article was already collected by another feed
+	existingItems := &models.LatestItems{
+		Items: []models.LatestItem{
+			{
+				Title:    "Second Article",
+				Link:     "https://example.com/second",
+				Category: "other",
+			},
+		},
+	}
+
+	// Test ProcessFeedConfig
+	result := ProcessFeedConfig(feedConfig, existingItems)
+	require.NotNil(t, result)
+	assert.NoError(t, result.Error)
+	assert.True(t, result.ConfigUpdated)
+	require.Len(t, result.NewItems, 2)
+	assert.Equal(t, "https://example.com/third", result.NewItems[0].Link)
+	assert.Equal(t, "https://example.com/first", result.NewItems[1].Link)
+
+	// Verify config was updated to the newest item
+	assert.Equal(t, "https://example.com/third", feedConfig.LatestLink)
+}
+
+func TestProcessFeedConfig_CanonicalLinks(t *testing.T) {
+	// Feed links carry tracking params and fragments
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>New Article</title>
+      <link>https://example.com/new/?utm_source=rss&amp;utm_medium=feed</link>
+      <pubDate>Tue, 07 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Collected Article</title>
+      <link>https://example.com/collected#atom-everything</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Old Article</title>
+      <link>http://Example.com/old?utm_campaign=x</link>
+      <pubDate>Sun, 05 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Test Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/old",
+		Category:   "test",
+	}
+	existingItems := &models.LatestItems{
+		Items: []models.LatestItem{{Title: "Collected Article", Link: "https://example.com/collected"}},
+	}
+
+	result := ProcessFeedConfig(feedConfig, existingItems)
+	require.NotNil(t, result)
+	assert.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 1)
+	assert.Equal(t, "https://example.com/new", result.NewItems[0].Link)
+	assert.Equal(t, "https://example.com/new", feedConfig.LatestLink)
+}
+
+func TestProcessFeedConfig_UnparsedDates(t *testing.T) {
+	// An old entry with a broken date at the top of the feed must not look like the newest one
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Stale Article</title>
+      <link>https://example.com/stale</link>
+      <pubDate>2019-13-45</pubDate>
+    </item>
+    <item>
+      <title>New Article</title>
+      <link>https://example.com/new</link>
+      <pubDate>Tue, 07 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Old Article</title>
+      <link>https://example.com/old</link>
+      <pubDate>Sun, 05 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Test Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/old",
+		Category:   "test",
+	}
+
+	result := ProcessFeedConfig(feedConfig, &models.LatestItems{})
+	require.NotNil(t, result)
+	assert.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 1)
+	assert.Equal(t, "https://example.com/new", result.NewItems[0].Link)
+	assert.Equal(t, []string{"2019-13-45"}, result.UnparsedDates)
 }
 
 func TestProcessFeedConfig_FetchError(t *testing.T) {
@@ -171,7 +333,7 @@ func TestProcessFeedConfig_FetchError(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Error(t, result.Error)
 	assert.False(t, result.ConfigUpdated)
-	assert.Nil(t, result.NewItem)
+	assert.Empty(t, result.NewItems)
 }
 
 func TestProcessAllFeeds(t *testing.T) {
@@ -250,7 +412,7 @@ func TestProcessAllFeeds(t *testing.T) {
 	}
 
 	// Test ProcessAllFeeds
-	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, false)
+	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, ProcessOptions{})
 	require.NoError(t, err)
 	assert.Len(t, newItems, 2)
 
@@ -319,7 +481,7 @@ func TestProcessAllFeeds_NoNewItems(t *testing.T) {
 	}
 
 	// Test ProcessAllFeeds
-	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, false)
+	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, ProcessOptions{})
 	require.NoError(t, err)
 	assert.Empty(t, newItems)
 }
@@ -348,7 +510,251 @@ func TestProcessAllFeeds_WithErrors(t *testing.T) {
 	}
 
 	// Test ProcessAllFeeds with error
-	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, false)
+	newItems, err := ProcessAllFeedsWithOptions(configMap, existingItems, ProcessOptions{})
 	assert.Error(t, err)
 	assert.Empty(t, newItems)
-}
\ No newline at end of file
+}
+
+// newMaxAgeTestServer serves an RSS feed with entries published an hour, 60 days and 90 days ago
+func newMaxAgeTestServer(t *testing.T) *httptest.Server {
+	now := time.Now().UTC()
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Fresh Article</title>
+      <link>https://example.com/fresh</link>
+      <pubDate>` + now.Add(-time.Hour).Format(time.RFC1123Z) + `</pubDate>
+    </item>
+    <item>
+      <title>Old Article</title>
+      <link>https://example.com/old</link>
+      <pubDate>` + now.Add(-60*24*time.Hour).Format(time.RFC1123Z) + `</pubDate>
+    </item>
+    <item>
+      <title>Oldest Article</title>
+      <link>https://example.com/oldest</link>
+      <pubDate>` + now.Add(-90*24*time.Hour).Format(time.RFC1123Z) + `</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(rssXML))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProcessFeedConfig_MaxAge(t *testing.T) {
+	server := newMaxAgeTestServer(t)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Test Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/oldest",
+		Category:   "test",
+	}
+
+	// The entry republished from 60 days ago is ignored
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher, MaxAge: DefaultMaxAge})
+	require.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 1)
+	assert.Equal(t, "https://example.com/fresh", result.NewItems[0].Link)
+	assert.Equal(t, "https://example.com/fresh", feedConfig.LatestLink)
+}
+
+func TestProcessFeedConfig_MaxAgeSeedsLatestLink(t *testing.T) {
+	server := newMaxAgeTestServer(t)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	// A new feed whose newest entry is older than its maxAge only gets its latest link seeded
+	feedConfig := &models.FeedConfig{
+		Name:     "New Feed",
+		Type:     "categoryIsUrl",
+		FeedURL:  server.URL,
+		MaxAge:   "30m",
+		Category: "test",
+	}
+
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	assert.Empty(t, result.NewItems)
+	assert.True(t, result.ConfigUpdated)
+	assert.Equal(t, "https://example.com/fresh", feedConfig.LatestLink)
+
+	// Once seeded, nothing changes until a new entry appears
+	result = processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	assert.Empty(t, result.NewItems)
+	assert.False(t, result.ConfigUpdated)
+}
+
+func TestProcessFeedConfig_MaxAgeDisabledPerFeed(t *testing.T) {
+	server := newMaxAgeTestServer(t)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Archive Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/oldest",
+		MaxAge:     "0",
+		Category:   "test",
+	}
+
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher, MaxAge: time.Minute})
+	require.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 2)
+	assert.Equal(t, "https://example.com/fresh", result.NewItems[0].Link)
+	assert.Equal(t, "https://example.com/old", result.NewItems[1].Link)
+}
+
+func TestFeedMaxAge(t *testing.T) {
+	maxAge, err := feedMaxAge(models.FeedConfig{}, DefaultMaxAge)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxAge, maxAge)
+
+	maxAge, err = feedMaxAge(models.FeedConfig{MaxAge: "168h"}, DefaultMaxAge)
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, maxAge)
+
+	maxAge, err = feedMaxAge(models.FeedConfig{MaxAge: "0"}, DefaultMaxAge)
+	require.NoError(t, err)
+	assert.Zero(t, maxAge)
+
+	_, err = feedMaxAge(models.FeedConfig{Name: "Broken", MaxAge: "30 days"}, DefaultMaxAge)
+	assert.ErrorContains(t, err, `invalid maxAge "30 days" for Broken`)
+}
+
+func TestRecentItems(t *testing.T) {
+	now := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	items := []models.LatestItem{
+		{Link: "https://example.com/new", PublishedAt: now.Add(-time.Hour)},
+		{Link: "https://example.com/undated"},
+		{Link: "https://example.com/old", PublishedAt: now.Add(-48 * time.Hour)},
+	}
+
+	var links []string
+	for _, item := range recentItems(items, 24*time.Hour, now) {
+		links = append(links, item.Link)
+	}
+	assert.Equal(t, []string{"https://example.com/new", "https://example.com/undated"}, links)
+	assert.Len(t, recentItems(items, 0, now), 3)
+}
+
+func TestProcessFeedConfig_MovedFeed(t *testing.T) {
+	server := newRedirectTestServer(t)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Moved Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL + "/moved",
+		LatestLink: "https://example.com/article",
+		Category:   "test",
+	}
+
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	assert.True(t, result.ConfigUpdated)
+	assert.Equal(t, server.URL+"/feed.xml", feedConfig.FeedURL)
+}
+
+func TestProcessFeedConfig_MovedFeedKeepFeedURLs(t *testing.T) {
+	server := newRedirectTestServer(t)
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	for _, path := range []string{"/moved", "/temporary"} {
+		feedConfig := &models.FeedConfig{
+			Name:       "Moved Feed",
+			Type:       "categoryIsUrl",
+			FeedURL:    server.URL + path,
+			LatestLink: "https://example.com/article",
+			Category:   "test",
+		}
+
+		result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher, KeepFeedURLs: path == "/moved"})
+		require.NoError(t, result.Error)
+		assert.False(t, result.ConfigUpdated)
+		assert.Equal(t, server.URL+path, feedConfig.FeedURL)
+	}
+}
+
+func TestProcessFeedConfig_GoneFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	feedConfig := &models.FeedConfig{Name: "Closed Blog", Type: "categoryIsUrl", FeedURL: server.URL, Category: "test"}
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	assert.ErrorIs(t, result.Error, ErrGone)
+}
+
+func TestProcessFeedConfig_IncludeExclude(t *testing.T) {
+	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Sponsored: Try our platform</title>
+      <link>https://example.com/sponsored</link>
+    </item>
+    <item>
+      <title>Edge APIs</title>
+      <link>https://example.com/edge</link>
+      <description>Building APIs with Hono on Workers</description>
+    </item>
+    <item>
+      <title>Next.js tips</title>
+      <link>https://example.com/nextjs</link>
+    </item>
+    <item>
+      <title>Old Article</title>
+      <link>https://example.com/old</link>
+    </item>
+  </channel>
+</rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(rssXML))
+	}))
+	defer server.Close()
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1, MaxRetries: -1})
+
+	feedConfig := &models.FeedConfig{
+		Name:       "Topic Feed",
+		Type:       "categoryIsUrl",
+		FeedURL:    server.URL,
+		LatestLink: "https://example.com/old",
+		Include:    []string{"hono", "/^Sponsored/"},
+		Exclude:    []string{"sponsored"},
+		Category:   "test",
+	}
+
+	result := processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	require.Len(t, result.NewItems, 1)
+	assert.Equal(t, "https://example.com/edge", result.NewItems[0].Link)
+	// The latest link advances past the filtered entries
+	assert.True(t, result.ConfigUpdated)
+	assert.Equal(t, "https://example.com/sponsored", feedConfig.LatestLink)
+
+	// Only filtered entries: nothing is collected but the latest link still advances
+	feedConfig.LatestLink = "https://example.com/old"
+	feedConfig.Include = []string{"rust"}
+	result = processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	assert.Empty(t, result.NewItems)
+	assert.True(t, result.ConfigUpdated)
+	assert.Equal(t, "https://example.com/sponsored", feedConfig.LatestLink)
+
+	// An invalid pattern is reported without fetching
+	feedConfig.Include = []string{"/(/"}
+	result = processFeedConfig(feedConfig, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	assert.Error(t, result.Error)
+}
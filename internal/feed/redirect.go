@@ -0,0 +1,67 @@
+package feed
+
+import (
+	"net/http"
+	"sync"
+)
+
+// movedFeeds records the feeds that permanently redirect (requested URL to final URL)
+// so that their feedUrl can be rewritten. A nil *movedFeeds records nothing
+type movedFeeds struct {
+	mu    sync.Mutex
+	moved map[string]string
+}
+
+// newMovedFeeds creates an empty movedFeeds
+func newMovedFeeds() *movedFeeds {
+	return &movedFeeds{moved: make(map[string]string)}
+}
+
+// record remembers that url permanently redirects to movedTo
+func (m *movedFeeds) record(url string, movedTo string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.moved[url] = movedTo
+}
+
+// take returns the URL that url permanently redirects to ("" if it does not) and forgets it
+func (m *movedFeeds) take(url string) string {
+	if m == nil {
+		return ""
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	movedTo := m.moved[url]
+	delete(m.moved, url)
+	return movedTo
+}
+
+// permanentRedirectURL returns the URL a response was reached at through permanent redirects
+// (301 Moved Permanently and 308 Permanent Redirect) from the requested URL.
+// Only the leading permanent redirects are followed, so a temporary redirect is never recorded.
+// Returns "" when the first redirect is not permanent or there was none
+func permanentRedirectURL(resp *http.Response) string {
+	// Walk the redirect chain back to the original request
+	var chain []*http.Request
+	for req := resp.Request; req != nil; req = req.Response.Request {
+		chain = append(chain, req)
+		if req.Response == nil {
+			break
+		}
+	}
+
+	movedTo := ""
+	for i := len(chain) - 2; i >= 0; i-- {
+		status := chain[i].Response.StatusCode
+		if status != http.StatusMovedPermanently && status != http.StatusPermanentRedirect {
+			break
+		}
+		movedTo = chain[i].URL.String()
+	}
+	return movedTo
+}
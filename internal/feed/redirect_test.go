@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedirectTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved-again", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-again", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/feed.xml", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/feed.xml", http.StatusFound)
+	})
+	mux.HandleFunc("/moved-then-temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temporary", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/feed.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPermanentRedirectURL(t *testing.T) {
+	server := newRedirectTestServer(t)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "permanent redirects", path: "/moved", expected: server.URL + "/feed.xml"},
+		{name: "temporary redirect", path: "/temporary", expected: ""},
+		{name: "permanent then temporary redirect", path: "/moved-then-temporary", expected: server.URL + "/temporary"},
+		{name: "no redirect", path: "/feed.xml", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected, permanentRedirectURL(resp))
+		})
+	}
+}
+
+func TestMovedFeeds(t *testing.T) {
+	moved := newMovedFeeds()
+	moved.record("https://old.example.com/feed", "https://new.example.com/feed")
+
+	assert.Equal(t, "https://new.example.com/feed", moved.take("https://old.example.com/feed"))
+	assert.Equal(t, "", moved.take("https://old.example.com/feed"))
+
+	var nilMoved *movedFeeds
+	nilMoved.record("https://old.example.com/feed", "https://new.example.com/feed")
+	assert.Equal(t, "", nilMoved.take("https://old.example.com/feed"))
+}
@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// doWithRetry sends a request built by newRequest, retrying transient failures
+// (network errors, 5xx, rate limiting) with jittered exponential backoff.
+// Server requested waits from Retry-After and X-RateLimit-Reset take precedence over the backoff
+func (f *Fetcher) doWithRetry(newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		f.rateLimiter.wait(req.URL.Host)
+		resp, err := f.Client.Do(req)
+
+		if attempt >= f.MaxRetries {
+			return resp, err
+		}
+
+		delay, reason, retry := f.retryDelay(resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain a little of the body so that the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		log.Printf("Retrying %s in %s (%s, retry %d/%d)", req.URL, delay.Round(time.Millisecond), reason, attempt+1, f.MaxRetries)
+		f.sleep(delay)
+	}
+}
+
+// retryDelay decides whether a response or error should be retried and how long to wait
+func (f *Fetcher) retryDelay(resp *http.Response, err error, attempt int) (time.Duration, string, bool) {
+	if err != nil {
+		if !isTransientError(err) {
+			return 0, "", false
+		}
+		return f.backoff(attempt), err.Error(), true
+	}
+
+	if wait, ok := rateLimitWait(resp, time.Now()); ok {
+		if wait > f.MaxRetryWait {
+			log.Printf("Not retrying %s: server asked to wait %s", resp.Request.URL, wait.Round(time.Second))
+			return 0, "", false
+		}
+		if wait <= 0 {
+			wait = f.backoff(attempt)
+		}
+		return wait, fmt.Sprintf("HTTP %d", resp.StatusCode), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return f.backoff(attempt), fmt.Sprintf("HTTP %d", resp.StatusCode), true
+	}
+
+	return 0, "", false
+}
+
+// backoff returns the jittered exponential backoff delay for the given attempt
+// The delay is picked uniformly between half and the full exponential delay
+func (f *Fetcher) backoff(attempt int) time.Duration {
+	delay := f.RetryBaseDelay << uint(attempt)
+	if delay <= 0 || delay > f.RetryMaxDelay {
+		delay = f.RetryMaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// rateLimitWait returns the wait requested by the server through Retry-After
+// or GitHub's X-RateLimit-Reset. The second value is false if the response is not rate limited
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusServiceUnavailable &&
+		resp.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if t, err := http.ParseTime(retryAfter); err == nil {
+			return t.Sub(now), true
+		}
+	}
+
+	// GitHub reports an exhausted quota with 403/429 and the reset time as a unix timestamp
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0).Sub(now) + time.Second, true
+		}
+	}
+
+	// 429 without any hint is still worth retrying with the normal backoff
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// isTransientError reports whether a request error is likely to go away on retry
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// hostRateLimiter spaces out requests to the same host by a minimum interval
+type hostRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+// newHostRateLimiter creates a hostRateLimiter. A non-positive interval disables rate limiting
+func newHostRateLimiter(interval time.Duration) *hostRateLimiter {
+	if interval <= 0 {
+		return nil
+	}
+	return &hostRateLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request to the host is allowed
+func (l *hostRateLimiter) wait(host string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	next := l.next[host]
+	if next.Before(now) {
+		next = now
+	}
+	l.next[host] = next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(next.Sub(now))
+}
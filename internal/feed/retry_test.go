@@ -0,0 +1,219 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFetcher creates a fetcher that records retry delays instead of sleeping
+func newTestFetcher(delays *[]time.Duration) *Fetcher {
+	fetcher := NewFetcher(FetcherConfig{
+		RetryBaseDelay:     100 * time.Millisecond,
+		RetryMaxDelay:      time.Second,
+		MaxRetryWait:       time.Minute,
+		MinRequestInterval: -1,
+	})
+	fetcher.sleep = func(d time.Duration) {
+		*delays = append(*delays, d)
+	}
+	return fetcher
+}
+
+const retryTestRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>Article</title>
+      <link>https://example.com/article</link>
+      <pubDate>Mon, 06 Nov 2023 10:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+func TestFetcher_RetriesServerErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(retryTestRSS))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := newTestFetcher(&delays)
+
+	item, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Flaky Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "Article", item.Title)
+	assert.Equal(t, 3, requests)
+	require.Len(t, delays, 2)
+	assert.GreaterOrEqual(t, delays[0], 50*time.Millisecond)
+	assert.LessOrEqual(t, delays[0], 100*time.Millisecond)
+	assert.GreaterOrEqual(t, delays[1], 100*time.Millisecond)
+	assert.LessOrEqual(t, delays[1], 200*time.Millisecond)
+}
+
+func TestFetcher_GivesUpAfterMaxRetries(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := newTestFetcher(&delays)
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Down Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP error 503")
+	assert.Equal(t, DefaultMaxRetries+1, requests)
+	assert.Len(t, delays, DefaultMaxRetries)
+}
+
+func TestFetcher_DoesNotRetryClientErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := newTestFetcher(&delays)
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Missing Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.Error(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Empty(t, delays)
+}
+
+func TestFetcher_HonoursRetryAfter(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(retryTestRSS))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := newTestFetcher(&delays)
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Busy Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, delays)
+}
+
+func TestFetcher_RetryAfterTooLong(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := newTestFetcher(&delays)
+
+	_, err := fetcher.FetchLatestItem(models.FeedConfig{Name: "Busy Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP error 429")
+	assert.Equal(t, 1, requests)
+	assert.Empty(t, delays)
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		status   int
+		header   http.Header
+		expected time.Duration
+		limited  bool
+	}{
+		{
+			name:     "Retry-After seconds",
+			status:   http.StatusTooManyRequests,
+			header:   http.Header{"Retry-After": {"30"}},
+			expected: 30 * time.Second,
+			limited:  true,
+		},
+		{
+			name:     "Retry-After HTTP date",
+			status:   http.StatusServiceUnavailable,
+			header:   http.Header{"Retry-After": {now.Add(time.Minute).UTC().Format(http.TimeFormat)}},
+			expected: time.Minute,
+			limited:  true,
+		},
+		{
+			name:   "GitHub rate limit reset",
+			status: http.StatusForbidden,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)},
+			},
+			expected: 11 * time.Second,
+			limited:  true,
+		},
+		{
+			name:    "Forbidden without rate limit headers",
+			status:  http.StatusForbidden,
+			header:  http.Header{},
+			limited: false,
+		},
+		{
+			name:     "Too many requests without hints",
+			status:   http.StatusTooManyRequests,
+			header:   http.Header{},
+			expected: 0,
+			limited:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, limited := rateLimitWait(&http.Response{StatusCode: tt.status, Header: tt.header}, now)
+			assert.Equal(t, tt.limited, limited)
+			assert.Equal(t, tt.expected, wait)
+		})
+	}
+}
+
+func TestHostRateLimiter(t *testing.T) {
+	limiter := newHostRateLimiter(20 * time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		limiter.wait("example.com")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// Other hosts are not delayed
+	start = time.Now()
+	limiter.wait("other.example.com")
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+
+	// A disabled limiter never waits
+	assert.Nil(t, newHostRateLimiter(-1))
+	var disabled *hostRateLimiter
+	disabled.wait("example.com")
+}
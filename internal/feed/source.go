@@ -0,0 +1,76 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"tech-feed-weekly/pkg/models"
+)
+
+// Source fetches the items of one feed type (FeedConfig.Type)
+// Each source owns its URL building, parsing and item mapping
+type Source interface {
+	// FeedURL builds the URL the feed is fetched from
+	FeedURL(baseURLs BaseURLs, config models.FeedConfig) string
+	// Fetch fetches the items of the feed sorted by date (latest first)
+	Fetch(f *Fetcher, config models.FeedConfig) ([]models.LatestItem, error)
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// RegisterSource makes a source available for the given feed type
+// It panics if the feed type is already registered
+func RegisterSource(feedType string, source Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if _, exists := sources[feedType]; exists {
+		panic(fmt.Sprintf("feed: source already registered for type %q", feedType))
+	}
+	sources[feedType] = source
+}
+
+// LookupSource returns the source registered for the feed type
+func LookupSource(feedType string) (Source, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	source, ok := sources[feedType]
+	return source, ok
+}
+
+// SourceTypes returns the registered feed types sorted by name
+func SourceTypes() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	var feedTypes []string
+	for feedType := range sources {
+		feedTypes = append(feedTypes, feedType)
+	}
+	sort.Strings(feedTypes)
+	return feedTypes
+}
+
+// feedParser parses a feed response into items sorted by date (latest first)
+type feedParser func(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error)
+
+// feedSource is a Source for feed documents served over HTTP
+type feedSource struct {
+	buildURL func(baseURLs BaseURLs, feedURL string) string
+	parse    feedParser
+}
+
+// FeedURL builds the URL the feed is fetched from
+func (s feedSource) FeedURL(baseURLs BaseURLs, config models.FeedConfig) string {
+	return s.buildURL(baseURLs, config.FeedURL)
+}
+
+// Fetch fetches and parses the feed
+func (s feedSource) Fetch(f *Fetcher, config models.FeedConfig) ([]models.LatestItem, error) {
+	return f.fetchFeed(s.FeedURL(f.BaseURLs, config), config, s.parse)
+}
@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+// parseAtomFeed parses Atom feed and returns its entries sorted by date (latest first)
+func parseAtomFeed(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error) {
+	var feed models.AtomFeed
+	decoder, err := newFeedDecoder(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode Atom feed: %w", err)
+	}
+
+	if len(feed.Entries) == 0 {
+		return nil, fmt.Errorf("no entries found in Atom feed")
+	}
+
+	// The published date is used for ordering since updated changes on every edit
+	var latestItems []models.LatestItem
+	for _, entry := range feed.Entries {
+		date, unparsedDate := parseEntryDate(firstNonEmpty(entry.Published, entry.Updated))
+		latestItems = append(latestItems, models.LatestItem{
+			Title:        strings.TrimSpace(entry.Title),
+			Link:         atomEntryLink(entry),
+			Category:     config.Category,
+			Description:  summarizeText(firstNonEmpty(entry.Summary, entry.Content)),
+			Author:       atomEntryAuthor(entry),
+			PublishedAt:  date,
+			UnparsedDate: unparsedDate,
+		})
+	}
+
+	sortItemsByDate(latestItems)
+	return latestItems, nil
+}
+
+// atomEntryAuthor returns the names of the authors of an Atom entry
+func atomEntryAuthor(entry models.AtomEntry) string {
+	var names []string
+	for _, author := range entry.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// atomEntryLink selects the permalink of an Atom entry
+// Preference order: rel="alternate" (or no rel) with an HTML type, any alternate link,
+// any link other than self/edit/replies/enclosure, and finally the entry id if it is a URL
+func atomEntryLink(entry models.AtomEntry) string {
+	isAlternate := func(link models.AtomLink) bool {
+		return link.Rel == "" || link.Rel == "alternate"
+	}
+	isHTML := func(link models.AtomLink) bool {
+		mediaType := strings.ToLower(strings.TrimSpace(link.Type))
+		return mediaType == "" || mediaType == "text/html" || mediaType == "application/xhtml+xml"
+	}
+
+	candidates := []func(models.AtomLink) bool{
+		func(link models.AtomLink) bool { return isAlternate(link) && isHTML(link) },
+		isAlternate,
+		func(link models.AtomLink) bool {
+			switch link.Rel {
+			case "self", "edit", "replies", "enclosure":
+				return false
+			}
+			return true
+		},
+	}
+
+	for _, matches := range candidates {
+		for _, link := range entry.Links {
+			href := strings.TrimSpace(link.Href)
+			if href != "" && matches(link) {
+				return href
+			}
+		}
+	}
+
+	id := strings.TrimSpace(entry.ID)
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package feed
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAtomFeed_LinkSelectionAndOrdering(t *testing.T) {
+	atomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <id>tag:example.com,2023:old-but-edited</id>
+    <title>Old Entry Edited Recently</title>
+    <link rel="replies" type="application/atom+xml" href="https://example.com/old/comments.atom"/>
+    <link rel="edit" href="https://example.com/api/old"/>
+    <link rel="alternate" type="text/html" href="https://example.com/old"/>
+    <published>2023-11-01T10:00:00Z</published>
+    <updated>2023-11-10T10:00:00Z</updated>
+  </entry>
+  <entry>
+    <id>tag:example.com,2023:new</id>
+    <title>New Entry</title>
+    <link rel="enclosure" type="audio/mpeg" href="https://example.com/new.mp3"/>
+    <link rel="alternate" type="application/json" href="https://example.com/new.json"/>
+    <link href="https://example.com/new"/>
+    <published>2023-11-06T10:00:00Z</published>
+    <updated>2023-11-06T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(atomXML))}
+	items, err := parseAtomFeed(resp, models.FeedConfig{Category: "test"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// Ordered by published, not updated
+	assert.Equal(t, "New Entry", items[0].Title)
+	assert.Equal(t, "https://example.com/new", items[0].Link)
+	assert.Equal(t, "Old Entry Edited Recently", items[1].Title)
+	assert.Equal(t, "https://example.com/old", items[1].Link)
+}
+
+func TestParseAtomFeed_Metadata(t *testing.T) {
+	atomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <title>With Summary</title>
+    <link href="https://example.com/summary"/>
+    <summary>Short &lt;b&gt;summary&lt;/b&gt;</summary>
+    <content type="html">Full content</content>
+    <author><name>Taro</name></author>
+    <author><name>Hanako</name></author>
+    <published>2023-11-06T10:00:00Z</published>
+  </entry>
+  <entry>
+    <title>Content Only</title>
+    <link href="https://example.com/content"/>
+    <content type="html">&lt;p&gt;Full   content&lt;/p&gt;</content>
+    <updated>2023-11-05T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(atomXML))}
+	items, err := parseAtomFeed(resp, models.FeedConfig{Category: "test"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "Short summary", items[0].Description)
+	assert.Equal(t, "Taro, Hanako", items[0].Author)
+	assert.Equal(t, time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC), items[0].PublishedAt)
+
+	// content is used when there is no summary
+	assert.Equal(t, "Full content", items[1].Description)
+	assert.Equal(t, "", items[1].Author)
+}
+
+func TestAtomEntryLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    models.AtomEntry
+		expected string
+	}{
+		{
+			name: "alternate html preferred over other links",
+			entry: models.AtomEntry{Links: []models.AtomLink{
+				{Rel: "self", Href: "https://example.com/self"},
+				{Rel: "alternate", Type: "text/html", Href: "https://example.com/html"},
+			}},
+			expected: "https://example.com/html",
+		},
+		{
+			name: "link without rel",
+			entry: models.AtomEntry{Links: []models.AtomLink{
+				{Rel: "edit", Href: "https://example.com/edit"},
+				{Href: "https://example.com/plain"},
+			}},
+			expected: "https://example.com/plain",
+		},
+		{
+			name: "non html alternate when no html link exists",
+			entry: models.AtomEntry{Links: []models.AtomLink{
+				{Rel: "replies", Href: "https://example.com/replies"},
+				{Rel: "alternate", Type: "application/pdf", Href: "https://example.com/doc.pdf"},
+			}},
+			expected: "https://example.com/doc.pdf",
+		},
+		{
+			name: "related link when no alternate exists",
+			entry: models.AtomEntry{Links: []models.AtomLink{
+				{Rel: "self", Href: "https://example.com/self"},
+				{Rel: "related", Href: "https://example.com/related"},
+			}},
+			expected: "https://example.com/related",
+		},
+		{
+			name: "id fallback",
+			entry: models.AtomEntry{
+				ID:    "https://example.com/from-id",
+				Links: []models.AtomLink{{Rel: "self", Href: "https://example.com/self"}},
+			},
+			expected: "https://example.com/from-id",
+		},
+		{
+			name:     "no usable link",
+			entry:    models.AtomEntry{ID: "tag:example.com,2023:1"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, atomEntryLink(tt.entry))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package feed
+
+import (
+	"net/url"
+	"strings"
+)
+
+func init() {
+	// connpass: connpass group feed (group name as feedUrl)
+	RegisterSource("connpass", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return connpassFeedURL(baseURLs.Connpass, feedURL)
+		},
+		parse: parseAtomFeed,
+	})
+}
+
+// connpassFeedURL builds the Atom feed URL of a connpass group, which lives on a subdomain
+func connpassFeedURL(baseURL string, group string) string {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+	parsedURL.Host = group + "." + parsedURL.Host
+	return strings.TrimSuffix(parsedURL.String(), "/") + "/ja.atom"
+}
@@ -0,0 +1,131 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+func init() {
+	// feed: any feed URL, the format (RSS 2.0, RSS 1.0, Atom or JSON Feed) is detected from the document
+	// categoryIsUrl and categoryIsAtomUrl are kept as aliases for existing configs
+	for _, feedType := range []string{"feed", "categoryIsUrl", "categoryIsAtomUrl"} {
+		RegisterSource(feedType, feedSource{
+			buildURL: func(baseURLs BaseURLs, feedURL string) string {
+				return feedURL
+			},
+			parse: parseDetectedFeed,
+		})
+	}
+}
+
+// feedFormat represents a feed document format
+type feedFormat string
+
+const (
+	formatUnknown feedFormat = ""
+	formatRSS     feedFormat = "rss"
+	formatAtom    feedFormat = "atom"
+	formatRDF     feedFormat = "rdf"
+	formatJSON    feedFormat = "json"
+)
+
+// feedParsers maps each detectable format to its parser
+var feedParsers = map[feedFormat]feedParser{
+	formatRSS:  parseRSSFeed,
+	formatAtom: parseAtomFeed,
+	formatRDF:  parseRDFFeed,
+	formatJSON: parseJSONFeed,
+}
+
+// parseDetectedFeed detects the format of the feed document and parses it with the matching parser
+func parseDetectedFeed(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	format := detectFeedFormat(resp.Header.Get("Content-Type"), body)
+	if format == formatUnknown {
+		return nil, fmt.Errorf("failed to decode feed: unknown feed format")
+	}
+
+	parse, ok := feedParsers[format]
+	if !ok {
+		return nil, fmt.Errorf("failed to decode feed: unsupported feed format %s", format)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return parse(resp, config)
+}
+
+// detectFeedFormat detects the feed format from the root element of the document,
+// falling back to the Content-Type header when the document cannot be sniffed
+func detectFeedFormat(contentType string, body []byte) feedFormat {
+	if isJSONFeed(body) {
+		return formatJSON
+	}
+
+	switch sniffRootElement(body) {
+	case "rss":
+		return formatRSS
+	case "feed":
+		return formatAtom
+	case "RDF":
+		return formatRDF
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/rss+xml":
+		return formatRSS
+	case "application/atom+xml":
+		return formatAtom
+	case "application/rdf+xml":
+		return formatRDF
+	case "application/feed+json":
+		return formatJSON
+	}
+
+	return formatUnknown
+}
+
+// isJSONFeed reports whether the document is a JSON object declaring a JSON Feed version
+func isJSONFeed(body []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return false
+	}
+
+	var header struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return false
+	}
+	return strings.HasPrefix(header.Version, "https://jsonfeed.org/version/")
+}
+
+// sniffRootElement returns the local name of the root element of an XML document
+func sniffRootElement(body []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	// Element names are ASCII, so the declared encoding does not matter for sniffing
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"tech-feed-weekly/internal/canonical"
+	"tech-feed-weekly/pkg/models"
+)
+
+func init() {
+	// github-issues: open issues and pull requests of a repository ("owner/repo" as feedUrl)
+	RegisterSource("github-issues", gitHubIssuesSource{})
+}
+
+// gitHubMaxPages is the maximum number of pages fetched while looking for the latest link
+const gitHubMaxPages = 5
+
+// GitHub issue kinds
+const (
+	gitHubKindIssue       = "issue"
+	gitHubKindPullRequest = "pr"
+)
+
+// gitHubNextLinkPattern matches the next page URL in a GitHub Link header
+var gitHubNextLinkPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="next"`)
+
+// gitHubIssuesSource is a Source for GitHub repository issues
+type gitHubIssuesSource struct{}
+
+// FeedURL builds the GitHub API URL listing the newest issues
+// Closed issues are listed too, so that the latest link is still found once it has been closed or merged
+func (gitHubIssuesSource) FeedURL(baseURLs BaseURLs, config models.FeedConfig) string {
+	return fmt.Sprintf("%s/repos/%s/issues?state=all&sort=created&direction=desc&per_page=100", baseURLs.GitHubAPI, config.FeedURL)
+}
+
+// Fetch fetches the open issues of a GitHub repository (latest first).
+// Pages are followed until config.LatestLink is reached, and the issues are filtered
+// by config.IssueKind, config.IncludeLabels and config.ExcludeLabels.
+// The issue at config.LatestLink is kept even when it is closed or filtered out, so that the
+// newer issues can be told apart. No matching issue is not an error
+func (s gitHubIssuesSource) Fetch(f *Fetcher, config models.FeedConfig) ([]models.LatestItem, error) {
+	if config.IssueKind != "" && config.IssueKind != gitHubKindIssue && config.IssueKind != gitHubKindPullRequest {
+		return nil, fmt.Errorf("unknown issueKind %q for %s", config.IssueKind, config.Name)
+	}
+
+	apiURL := s.FeedURL(f.BaseURLs, config)
+
+	var issues []models.GitHubIssue
+	var firstResp *http.Response
+	for page := 0; apiURL != "" && page < gitHubMaxPages; page++ {
+		// Only the first page is requested conditionally, later pages change whenever it does
+		var pageIssues []models.GitHubIssue
+		resp, err := f.getGitHubJSON(apiURL, &pageIssues, page == 0)
+		if err != nil {
+			return nil, err
+		}
+		if page == 0 {
+			firstResp = resp
+		}
+		issues = append(issues, pageIssues...)
+
+		// A first run only needs the latest issue
+		if config.LatestLink == "" || containsGitHubIssue(pageIssues, config.LatestLink) {
+			break
+		}
+		apiURL = gitHubNextPageURL(resp.Header.Get("Link"))
+	}
+
+	// The API already returns issues sorted by creation date (latest first)
+	var items []models.LatestItem
+	for _, issue := range issues {
+		latest := config.LatestLink != "" && canonical.Equal(issue.HTMLURL, config.LatestLink)
+		if !latest && (issue.State == "closed" || !gitHubIssueMatches(issue, config)) {
+			continue
+		}
+		items = append(items, gitHubIssueItem(issue, config))
+	}
+
+	// Remember the validators only once every page has been fetched
+	f.Cache.storeValidators(s.FeedURL(f.BaseURLs, config), firstResp)
+	return items, nil
+}
+
+// gitHubIssueItem converts a GitHub issue to a latest item
+func gitHubIssueItem(issue models.GitHubIssue, config models.FeedConfig) models.LatestItem {
+	var labels []string
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return models.LatestItem{
+		Title:       strings.TrimSpace(issue.Title),
+		Link:        strings.TrimSpace(issue.HTMLURL),
+		Category:    config.Category,
+		Description: summarizeText(issue.Body),
+		Number:      issue.Number,
+		Author:      issue.User.Login,
+		Labels:      labels,
+		Kind:        gitHubIssueKind(issue),
+		PublishedAt: issue.CreatedAt,
+	}
+}
+
+// gitHubIssueKind returns whether the issue is a plain issue or a pull request
+func gitHubIssueKind(issue models.GitHubIssue) string {
+	if issue.PullRequest != nil {
+		return gitHubKindPullRequest
+	}
+	return gitHubKindIssue
+}
+
+// gitHubIssueMatches reports whether the issue passes the kind and label filters of the feed
+func gitHubIssueMatches(issue models.GitHubIssue, config models.FeedConfig) bool {
+	if config.IssueKind != "" && gitHubIssueKind(issue) != config.IssueKind {
+		return false
+	}
+
+	if len(config.IncludeLabels) > 0 && !hasGitHubLabel(issue, config.IncludeLabels) {
+		return false
+	}
+	return !hasGitHubLabel(issue, config.ExcludeLabels)
+}
+
+// hasGitHubLabel reports whether the issue has any of the labels (case-insensitive)
+func hasGitHubLabel(issue models.GitHubIssue, labels []string) bool {
+	for _, label := range issue.Labels {
+		for _, name := range labels {
+			if strings.EqualFold(label.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsGitHubIssue reports whether an issue with the given link is in the list
+func containsGitHubIssue(issues []models.GitHubIssue, link string) bool {
+	for _, issue := range issues {
+		if canonical.Equal(issue.HTMLURL, link) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitHubNextPageURL extracts the next page URL from a GitHub Link header ("" on the last page)
+func gitHubNextPageURL(linkHeader string) string {
+	match := gitHubNextLinkPattern.FindStringSubmatch(linkHeader)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// getGitHubJSON fetches a GitHub API endpoint and decodes its JSON response into v.
+// The request is authenticated when the fetcher has a GitHub token. When conditional is set,
+// cached validators are sent; storing the new ones is left to the caller
+func (f *Fetcher) getGitHubJSON(apiURL string, v any, conditional bool) (*http.Response, error) {
+	header := http.Header{"Accept": {"application/vnd.github.v3+json"}}
+	if f.GitHubToken != "" {
+		header.Set("Authorization", "Bearer "+f.GitHubToken)
+	}
+
+	// Conditional requests answered with 304 do not count against the GitHub API rate limit
+	cache := f.Cache
+	if !conditional {
+		cache = nil
+	}
+	resp, err := f.getWithCache(apiURL, header, cache)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub API JSON from %s: %w", apiURL, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+func init() {
+	// github-releases: published releases of a repository ("owner/repo" as feedUrl)
+	RegisterSource("github-releases", gitHubReleasesSource{})
+}
+
+// gitHubReleasesSource is a Source for GitHub repository releases
+type gitHubReleasesSource struct{}
+
+// FeedURL builds the GitHub API URL listing the releases of the repository
+func (gitHubReleasesSource) FeedURL(baseURLs BaseURLs, config models.FeedConfig) string {
+	return fmt.Sprintf("%s/repos/%s/releases", baseURLs.GitHubAPI, config.FeedURL)
+}
+
+// Fetch fetches the published releases of a GitHub repository (latest first).
+// Drafts are always skipped, prereleases when config.SkipPrereleases is set
+func (s gitHubReleasesSource) Fetch(f *Fetcher, config models.FeedConfig) ([]models.LatestItem, error) {
+	apiURL := s.FeedURL(f.BaseURLs, config)
+
+	var releases []models.GitHubRelease
+	resp, err := f.getGitHubJSON(apiURL, &releases, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var published []models.GitHubRelease
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && config.SkipPrereleases) {
+			continue
+		}
+		published = append(published, release)
+	}
+
+	if len(published) == 0 {
+		return nil, fmt.Errorf("no releases found for %s", config.FeedURL)
+	}
+
+	// Releases are listed by creation date, which differs from the publish date for releases created as drafts
+	sort.SliceStable(published, func(i, j int) bool {
+		return published[i].PublishedAt.After(published[j].PublishedAt)
+	})
+
+	var items []models.LatestItem
+	for _, release := range published {
+		items = append(items, models.LatestItem{
+			Title:       gitHubReleaseTitle(config.FeedURL, release),
+			Link:        strings.TrimSpace(release.HTMLURL),
+			Category:    config.Category,
+			Description: summarizeText(release.Body),
+			Author:      release.Author.Login,
+			PublishedAt: release.PublishedAt,
+		})
+	}
+
+	f.Cache.storeValidators(apiURL, resp)
+	return items, nil
+}
+
+// gitHubReleaseTitle builds an item title from the repository, the tag and the release name
+func gitHubReleaseTitle(repository string, release models.GitHubRelease) string {
+	tag := strings.TrimSpace(release.TagName)
+	name := strings.TrimSpace(release.Name)
+
+	title := fmt.Sprintf("%s %s", repository, tag)
+	if name != "" && name != tag {
+		title = fmt.Sprintf("%s: %s", title, name)
+	}
+	return title
+}
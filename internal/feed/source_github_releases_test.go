@@ -0,0 +1,94 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitHubReleasesJSON = `[
+	{"tag_name": "v2.0.0-rc.1", "name": "v2.0.0-rc.1", "html_url": "https://github.com/owner/repo/releases/tag/v2.0.0-rc.1", "draft": false, "prerelease": true, "published_at": "2023-11-07T10:00:00Z"},
+	{"tag_name": "v1.3.0", "name": "", "html_url": "https://github.com/owner/repo/releases/tag/v1.3.0", "draft": true, "prerelease": false, "published_at": null},
+	{"tag_name": "v1.1.0", "name": "Faster routing", "html_url": "https://github.com/owner/repo/releases/tag/v1.1.0", "draft": false, "prerelease": false, "published_at": "2023-11-05T10:00:00Z"},
+	{"tag_name": "v1.2.0", "name": "v1.2.0", "html_url": "https://github.com/owner/repo/releases/tag/v1.2.0", "draft": false, "prerelease": false, "published_at": "2023-11-06T10:00:00Z", "author": {"login": "octocat"}, "body": "## Changes\n\n* Faster startup"}
+]`
+
+func newGitHubReleasesServer(t *testing.T, requestURI *string) *Fetcher {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requestURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(gitHubReleasesJSON))
+	}))
+	t.Cleanup(server.Close)
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.GitHubAPI = server.URL
+	return NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MinRequestInterval: -1})
+}
+
+func TestGitHubReleasesSource(t *testing.T) {
+	var requestURI string
+	fetcher := newGitHubReleasesServer(t, &requestURI)
+
+	items, err := fetcher.FetchItems(models.FeedConfig{Name: "Repo", Type: "github-releases", FeedURL: "owner/repo", Category: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/owner/repo/releases", requestURI)
+
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title)
+		assert.Equal(t, "test", item.Category)
+	}
+	assert.Equal(t, []string{
+		"owner/repo v2.0.0-rc.1",
+		"owner/repo v1.2.0",
+		"owner/repo v1.1.0: Faster routing",
+	}, titles)
+	assert.Equal(t, "https://github.com/owner/repo/releases/tag/v2.0.0-rc.1", items[0].Link)
+
+	assert.Equal(t, "octocat", items[1].Author)
+	assert.Equal(t, "## Changes * Faster startup", items[1].Description)
+	assert.Equal(t, time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC), items[1].PublishedAt)
+	assert.Equal(t, "Repo", items[1].FeedName)
+}
+
+func TestGitHubReleasesSource_SkipPrereleases(t *testing.T) {
+	var requestURI string
+	fetcher := newGitHubReleasesServer(t, &requestURI)
+
+	config := models.FeedConfig{
+		Name:            "Repo",
+		Type:            "github-releases",
+		FeedURL:         "owner/repo",
+		LatestLink:      "https://github.com/owner/repo/releases/tag/v1.1.0",
+		SkipPrereleases: true,
+	}
+	items, err := fetcher.FetchNewItems(config, 0)
+	require.NoError(t, err)
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "owner/repo v1.2.0", items[0].Title)
+	assert.Equal(t, "https://github.com/owner/repo/releases/tag/v1.2.0", items[0].Link)
+}
+
+func TestGitHubReleasesSource_NoReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"tag_name": "v1.0.0", "draft": true}]`))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.GitHubAPI = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MinRequestInterval: -1})
+
+	items, err := fetcher.FetchItems(models.FeedConfig{Name: "Repo", Type: "github-releases", FeedURL: "owner/repo"})
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Contains(t, err.Error(), "no releases found")
+}
@@ -0,0 +1,227 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitHubIssuesServer serves two pages of issues, the second one linked from the first
+func newGitHubIssuesServer(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`[
+				{"number": 2, "title": "Old PR", "html_url": "https://github.com/owner/repo/pull/2", "user": {"login": "carol"}, "pull_request": {"html_url": "https://github.com/owner/repo/pull/2"}},
+				{"number": 1, "title": "First issue", "html_url": "https://github.com/owner/repo/issues/1", "user": {"login": "dave"}}
+			]`))
+			return
+		}
+
+		w.Header().Set("ETag", `"page1"`)
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repositories/1/issues?page=2>; rel="next", <%s/repositories/1/issues?page=2>; rel="last"`, server.URL, server.URL))
+		w.Write([]byte(`[
+			{"number": 5, "title": "Add feature", "html_url": "https://github.com/owner/repo/pull/5", "user": {"login": "alice"}, "labels": [{"name": "enhancement"}], "created_at": "2023-11-06T10:00:00Z", "body": "Adds the <b>feature</b>", "pull_request": {"html_url": "https://github.com/owner/repo/pull/5"}},
+			{"number": 4, "title": "Crash on start", "html_url": "https://github.com/owner/repo/issues/4", "user": {"login": "bob"}, "labels": [{"name": "bug"}, {"name": "wontfix"}]},
+			{"number": 3, "title": "Docs typo", "html_url": "https://github.com/owner/repo/issues/3", "user": {"login": "bob"}, "labels": [{"name": "Bug"}]}
+		]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newGitHubTestFetcher(server *httptest.Server, token string) *Fetcher {
+	baseURLs := DefaultBaseURLs()
+	baseURLs.GitHubAPI = server.URL
+	return NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MinRequestInterval: -1, GitHubToken: token})
+}
+
+func TestGitHubIssuesSource_ItemFields(t *testing.T) {
+	var requests []*http.Request
+	server := newGitHubIssuesServer(t, &requests)
+	fetcher := newGitHubTestFetcher(server, "")
+
+	items, err := fetcher.FetchItems(models.FeedConfig{Name: "Repo", Type: "github-issues", FeedURL: "owner/repo", Category: "test"})
+	require.NoError(t, err)
+
+	// A first run does not page
+	require.Len(t, requests, 1)
+	assert.Empty(t, requests[0].Header.Get("Authorization"))
+
+	require.Len(t, items, 3)
+	assert.Equal(t, models.LatestItem{
+		Title:       "Add feature",
+		Link:        "https://github.com/owner/repo/pull/5",
+		Category:    "test",
+		Description: "Adds the feature",
+		Number:      5,
+		Author:      "alice",
+		Labels:      []string{"enhancement"},
+		Kind:        "pr",
+		PublishedAt: time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC),
+		FeedName:    "Repo",
+	}, items[0])
+	assert.Equal(t, "issue", items[1].Kind)
+	assert.Equal(t, []string{"bug", "wontfix"}, items[1].Labels)
+}
+
+func TestGitHubIssuesSource_PagesUntilLatestLink(t *testing.T) {
+	var requests []*http.Request
+	server := newGitHubIssuesServer(t, &requests)
+	fetcher := newGitHubTestFetcher(server, "secret")
+	fetcher.Cache = NewResponseCache(nil)
+
+	config := models.FeedConfig{
+		Name:       "Repo",
+		Type:       "github-issues",
+		FeedURL:    "owner/repo",
+		LatestLink: "https://github.com/owner/repo/issues/1",
+	}
+	items, err := fetcher.FetchNewItems(config, 0)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "2", requests[1].URL.Query().Get("page"))
+	for _, r := range requests {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+	}
+
+	var numbers []int
+	for _, item := range items {
+		numbers = append(numbers, item.Number)
+	}
+	assert.Equal(t, []int{5, 4, 3, 2}, numbers)
+
+	// Only the first page is cached for conditional requests
+	firstURL := gitHubIssuesSource{}.FeedURL(fetcher.BaseURLs, config)
+	assert.Equal(t, map[string]models.HTTPCacheEntry{firstURL: {ETag: `"page1"`}}, fetcher.Cache.Data().Entries)
+}
+
+func TestGitHubIssuesSource_ClosedLatestLink(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", `<http://example.invalid/repositories/1/issues?page=2>; rel="next"`)
+		w.Write([]byte(`[
+			{"number": 8, "state": "open", "title": "Add Bun adapter", "html_url": "https://github.com/owner/repo/pull/8", "user": {"login": "alice"}, "pull_request": {"html_url": "https://github.com/owner/repo/pull/8"}},
+			{"number": 7, "state": "closed", "title": "Fix typo", "html_url": "https://github.com/owner/repo/pull/7", "user": {"login": "bob"}, "pull_request": {"html_url": "https://github.com/owner/repo/pull/7"}},
+			{"number": 6, "state": "open", "title": "Crash on start", "html_url": "https://github.com/owner/repo/issues/6", "user": {"login": "carol"}},
+			{"number": 5, "state": "closed", "title": "Add Deno adapter", "html_url": "https://github.com/owner/repo/pull/5", "user": {"login": "dave"}, "pull_request": {"html_url": "https://github.com/owner/repo/pull/5"}}
+		]`))
+	}))
+	defer server.Close()
+	fetcher := newGitHubTestFetcher(server, "")
+
+	// The recorded pull request has been merged since the previous run
+	config := models.FeedConfig{
+		Name:       "Repo",
+		Type:       "github-issues",
+		FeedURL:    "owner/repo",
+		LatestLink: "https://github.com/owner/repo/pull/5",
+	}
+	items, err := fetcher.FetchNewItems(config, 0)
+	require.NoError(t, err)
+
+	// It is still found on the first page, and only the open issues newer than it are new
+	require.Len(t, requests, 1)
+	assert.Equal(t, "all", requests[0].URL.Query().Get("state"))
+	var numbers []int
+	for _, item := range items {
+		numbers = append(numbers, item.Number)
+	}
+	assert.Equal(t, []int{8, 6}, numbers)
+}
+
+func TestGitHubIssuesSource_Filters(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   models.FeedConfig
+		expected []int
+	}{
+		{
+			name:     "issues only",
+			config:   models.FeedConfig{IssueKind: "issue"},
+			expected: []int{4, 3},
+		},
+		{
+			name:     "pull requests only",
+			config:   models.FeedConfig{IssueKind: "pr"},
+			expected: []int{5},
+		},
+		{
+			name:     "include labels",
+			config:   models.FeedConfig{IncludeLabels: []string{"bug", "enhancement"}},
+			expected: []int{5, 4, 3},
+		},
+		{
+			name:     "include and exclude labels",
+			config:   models.FeedConfig{IncludeLabels: []string{"bug"}, ExcludeLabels: []string{"WONTFIX"}},
+			expected: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*http.Request
+			server := newGitHubIssuesServer(t, &requests)
+			fetcher := newGitHubTestFetcher(server, "")
+
+			config := tt.config
+			config.Name = "Repo"
+			config.Type = "github-issues"
+			config.FeedURL = "owner/repo"
+			items, err := fetcher.FetchItems(config)
+			require.NoError(t, err)
+
+			var numbers []int
+			for _, item := range items {
+				numbers = append(numbers, item.Number)
+			}
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+}
+
+func TestGitHubIssuesSource_NoMatchingIssue(t *testing.T) {
+	var requests []*http.Request
+	server := newGitHubIssuesServer(t, &requests)
+	fetcher := newGitHubTestFetcher(server, "")
+
+	// Filters matching nothing are a valid state, not a failure of the feed
+	config := &models.FeedConfig{Name: "Repo", Type: "github-issues", FeedURL: "owner/repo", IncludeLabels: []string{"good first issue"}, Category: "test"}
+	items, err := fetcher.FetchItems(*config)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	result := processFeedConfig(config, &models.LatestItems{}, ProcessOptions{Fetcher: fetcher})
+	require.NoError(t, result.Error)
+	assert.Empty(t, result.NewItems)
+	assert.False(t, result.ConfigUpdated)
+
+	_, err = fetcher.FetchLatestItem(*config)
+	assert.Error(t, err)
+}
+
+func TestGitHubIssuesSource_UnknownKind(t *testing.T) {
+	items, err := defaultFetcher.FetchItems(models.FeedConfig{Name: "Repo", Type: "github-issues", FeedURL: "owner/repo", IssueKind: "discussion"})
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Contains(t, err.Error(), `unknown issueKind "discussion"`)
+}
+
+func TestGitHubNextPageURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/repositories/1/issues?page=3",
+		gitHubNextPageURL(`<https://api.github.com/repositories/1/issues?page=1>; rel="prev", <https://api.github.com/repositories/1/issues?page=3>; rel="next"`))
+	assert.Equal(t, "", gitHubNextPageURL(`<https://api.github.com/repositories/1/issues?page=1>; rel="first"`))
+	assert.Equal(t, "", gitHubNextPageURL(""))
+}
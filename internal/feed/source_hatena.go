@@ -0,0 +1,13 @@
+package feed
+
+import "fmt"
+
+func init() {
+	// hatena: Hatena blog feed (blog URL as feedUrl)
+	RegisterSource("hatena", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return fmt.Sprintf("%s/rss", feedURL)
+		},
+		parse: parseRSSFeed,
+	})
+}
@@ -0,0 +1,86 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+func init() {
+	// jsonfeed: JSON Feed (https://jsonfeed.org) URL
+	RegisterSource("jsonfeed", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return feedURL
+		},
+		parse: parseJSONFeed,
+	})
+}
+
+// parseJSONFeed parses JSON Feed 1.0/1.1 and returns its items sorted by date (latest first)
+func parseJSONFeed(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error) {
+	var feed models.JSONFeed
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON feed: %w", err)
+	}
+
+	var latestItems []models.LatestItem
+	for _, item := range feed.Items {
+		if jsonFeedItemLink(item) == "" {
+			continue
+		}
+
+		title := strings.TrimSpace(item.Title)
+		if title == "" {
+			// Title is optional in JSON Feed (e.g. microblog posts)
+			title = strings.TrimSpace(item.Summary)
+		}
+		date, unparsedDate := parseEntryDate(firstNonEmpty(item.DatePublished, item.DateModified))
+		latestItems = append(latestItems, models.LatestItem{
+			Title:        title,
+			Link:         jsonFeedItemLink(item),
+			Category:     config.Category,
+			Description:  summarizeText(firstNonEmpty(item.Summary, item.ContentText)),
+			Author:       jsonFeedItemAuthor(item),
+			PublishedAt:  date,
+			UnparsedDate: unparsedDate,
+		})
+	}
+
+	if len(latestItems) == 0 {
+		return nil, fmt.Errorf("no items found in JSON feed")
+	}
+
+	sortItemsByDate(latestItems)
+	return latestItems, nil
+}
+
+// jsonFeedItemAuthor returns the names of the authors of a JSON Feed item
+// (authors in JSON Feed 1.1, author in 1.0)
+func jsonFeedItemAuthor(item models.JSONFeedItem) string {
+	authors := item.Authors
+	if len(authors) == 0 && item.Author != nil {
+		authors = []models.JSONFeedAuthor{*item.Author}
+	}
+
+	var names []string
+	for _, author := range authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// jsonFeedItemLink returns the permalink of a JSON Feed item
+// falling back to external_url and to id when it is a URL
+func jsonFeedItemLink(item models.JSONFeedItem) string {
+	for _, link := range []string{item.URL, item.ExternalURL, item.ID} {
+		link = strings.TrimSpace(link)
+		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+			return link
+		}
+	}
+	return ""
+}
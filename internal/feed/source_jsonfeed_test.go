@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Test JSON Feed",
+  "items": [
+    {
+      "id": "1",
+      "url": "https://example.com/older",
+      "title": "Older Post",
+      "content_text": "Older content",
+      "author": {"name": "Jiro"},
+      "date_published": "2023-11-05T10:00:00Z"
+    },
+    {
+      "id": "https://example.com/microblog",
+      "summary": "A post without a title",
+      "date_modified": "2023-11-07T10:00:00Z"
+    },
+    {
+      "id": "2",
+      "url": "https://example.com/latest",
+      "title": " Latest Post ",
+      "summary": "Latest summary",
+      "content_text": "Latest content",
+      "authors": [{"name": "Taro"}, {"name": "Hanako"}],
+      "date_published": "2023-11-06T10:00:00+09:00",
+      "date_modified": "2023-11-08T10:00:00Z"
+    },
+    {
+      "id": "no-link",
+      "title": "Item without URL"
+    }
+  ]
+}`
+
+func TestFetchItems_JSONFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testJSONFeed))
+	}))
+	defer server.Close()
+
+	for _, feedType := range []string{"jsonfeed", "feed"} {
+		t.Run(feedType, func(t *testing.T) {
+			fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+			items, err := fetcher.FetchItems(models.FeedConfig{Name: "JSON Feed", Type: feedType, FeedURL: server.URL, Category: "test"})
+			require.NoError(t, err)
+			require.Len(t, items, 3)
+
+			// date_modified is only used when date_published is missing
+			assert.Equal(t, "https://example.com/microblog", items[0].Link)
+			assert.Equal(t, "A post without a title", items[0].Title)
+
+			assert.Equal(t, "https://example.com/latest", items[1].Link)
+			assert.Equal(t, "Latest Post", items[1].Title)
+			assert.Equal(t, "Latest summary", items[1].Description)
+			assert.Equal(t, "Taro, Hanako", items[1].Author)
+			assert.Equal(t, "test", items[1].Category)
+
+			assert.Equal(t, "https://example.com/older", items[2].Link)
+			// content_text is used when there is no summary, and author is the JSON Feed 1.0 form
+			assert.Equal(t, "Older content", items[2].Description)
+			assert.Equal(t, "Jiro", items[2].Author)
+		})
+	}
+}
+
+func TestDetectFeedFormat_JSONFeed(t *testing.T) {
+	assert.Equal(t, formatJSON, detectFeedFormat("application/json", []byte(testJSONFeed)))
+	assert.Equal(t, formatJSON, detectFeedFormat("application/feed+json", []byte(`not json`)))
+	assert.Equal(t, formatUnknown, detectFeedFormat("application/json", []byte(`{"version": "1.0"}`)))
+}
+
+func TestJSONFeedItemLink(t *testing.T) {
+	assert.Equal(t, "https://example.com/a", jsonFeedItemLink(models.JSONFeedItem{URL: "https://example.com/a", ID: "https://example.com/b"}))
+	assert.Equal(t, "https://example.com/ext", jsonFeedItemLink(models.JSONFeedItem{ExternalURL: "https://example.com/ext"}))
+	assert.Equal(t, "https://example.com/id", jsonFeedItemLink(models.JSONFeedItem{ID: "https://example.com/id"}))
+	assert.Equal(t, "", jsonFeedItemLink(models.JSONFeedItem{ID: "tag:example.com,2023:1"}))
+}
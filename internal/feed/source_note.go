@@ -0,0 +1,13 @@
+package feed
+
+import "fmt"
+
+func init() {
+	// note: note user feed (username as feedUrl)
+	RegisterSource("note", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return fmt.Sprintf("%s/%s/rss", baseURLs.Note, feedURL)
+		},
+		parse: parseRSSFeed,
+	})
+}
@@ -0,0 +1,13 @@
+package feed
+
+import "fmt"
+
+func init() {
+	// qiita: Qiita user feed (username as feedUrl)
+	RegisterSource("qiita", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return fmt.Sprintf("%s/%s/feed", baseURLs.Qiita, feedURL)
+		},
+		parse: parseAtomFeed,
+	})
+}
@@ -0,0 +1,56 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+func init() {
+	// rdf: RSS 1.0 (RDF) feed URL
+	RegisterSource("rdf", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return feedURL
+		},
+		parse: parseRDFFeed,
+	})
+}
+
+// parseRDFFeed parses RSS 1.0 (RDF) feed and returns its items sorted by date (latest first)
+// Items sit directly under rdf:RDF and are usually dated with dc:date
+func parseRDFFeed(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error) {
+	var feed models.RDFFeed
+	decoder, err := newFeedDecoder(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode RDF feed: %w", err)
+	}
+
+	if len(feed.Items) == 0 {
+		return nil, fmt.Errorf("no items found in RDF feed")
+	}
+
+	var latestItems []models.LatestItem
+	for _, item := range feed.Items {
+		link := strings.TrimSpace(item.Link)
+		if link == "" {
+			link = strings.TrimSpace(item.About)
+		}
+		date, unparsedDate := parseEntryDate(firstNonEmpty(item.DCDate, item.PubDate))
+		latestItems = append(latestItems, models.LatestItem{
+			Title:        strings.TrimSpace(item.Title),
+			Link:         link,
+			Category:     config.Category,
+			Description:  summarizeText(item.Description),
+			Author:       strings.TrimSpace(item.DCCreator),
+			PublishedAt:  date,
+			UnparsedDate: unparsedDate,
+		})
+	}
+
+	sortItemsByDate(latestItems)
+	return latestItems, nil
+}
@@ -0,0 +1,107 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRDFFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns="http://purl.org/rss/1.0/"
+         xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel rdf:about="https://blog.example.jp/">
+    <title>Example Blog</title>
+    <link>https://blog.example.jp/</link>
+    <items>
+      <rdf:Seq>
+        <rdf:li rdf:resource="https://blog.example.jp/older"/>
+        <rdf:li rdf:resource="https://blog.example.jp/latest"/>
+      </rdf:Seq>
+    </items>
+  </channel>
+  <item rdf:about="https://blog.example.jp/older">
+    <title>Older Entry</title>
+    <link>https://blog.example.jp/older</link>
+    <dc:date>2023-11-05T10:00+09:00</dc:date>
+  </item>
+  <item rdf:about="https://blog.example.jp/latest">
+    <title> Latest Entry </title>
+    <link>https://blog.example.jp/latest</link>
+    <description>Latest description</description>
+    <dc:creator>Hanako</dc:creator>
+    <dc:date>2023-11-06T10:00:00+09:00</dc:date>
+  </item>
+  <item rdf:about="https://blog.example.jp/no-link">
+    <title>Entry without link element</title>
+    <dc:date>2023-11-04</dc:date>
+  </item>
+</rdf:RDF>`
+
+func TestFetchItems_RDFFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rdf+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testRDFFeed))
+	}))
+	defer server.Close()
+
+	for _, feedType := range []string{"rdf", "feed", "categoryIsUrl"} {
+		t.Run(feedType, func(t *testing.T) {
+			fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+			items, err := fetcher.FetchItems(models.FeedConfig{Name: "RDF Feed", Type: feedType, FeedURL: server.URL, Category: "test"})
+			require.NoError(t, err)
+			require.Len(t, items, 3)
+
+			assert.Equal(t, "Latest Entry", items[0].Title)
+			assert.Equal(t, "https://blog.example.jp/latest", items[0].Link)
+			assert.Equal(t, "Latest description", items[0].Description)
+			assert.Equal(t, "Hanako", items[0].Author)
+			assert.Equal(t, "2023-11-06T01:00:00Z", items[0].PublishedAt.UTC().Format(time.RFC3339))
+			assert.Equal(t, "RDF Feed", items[0].FeedName)
+			assert.Equal(t, "test", items[0].Category)
+
+			assert.Equal(t, "https://blog.example.jp/older", items[1].Link)
+
+			// rdf:about is used when the item has no link element
+			assert.Equal(t, "https://blog.example.jp/no-link", items[2].Link)
+		})
+	}
+}
+
+func TestFetchItems_RDFFeedEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"></rdf:RDF>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+	_, err := fetcher.FetchItems(models.FeedConfig{Name: "Empty RDF", Type: "rdf", FeedURL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no items found")
+}
+
+func TestFetchItems_RSSWithDCDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <item><title>Older</title><link>https://example.com/older</link><dc:date>2023-11-05T10:00:00Z</dc:date></item>
+    <item><title>Latest</title><link>https://example.com/latest</link><dc:date>2023-11-06T10:00:00Z</dc:date></item>
+  </channel>
+</rss>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(FetcherConfig{MinRequestInterval: -1})
+	items, err := fetcher.FetchItems(models.FeedConfig{Name: "RSS", Type: "feed", FeedURL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/latest", items[0].Link)
+}
@@ -0,0 +1,41 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+)
+
+// parseRSSFeed parses RSS feed and returns its items sorted by date (latest first)
+func parseRSSFeed(resp *http.Response, config models.FeedConfig) ([]models.LatestItem, error) {
+	var feed models.RSSFeed
+	decoder, err := newFeedDecoder(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode RSS feed: %w", err)
+	}
+
+	if len(feed.Channel.Items) == 0 {
+		return nil, fmt.Errorf("no items found in RSS feed")
+	}
+
+	var latestItems []models.LatestItem
+	for _, item := range feed.Channel.Items {
+		date, unparsedDate := parseEntryDate(firstNonEmpty(item.PubDate, item.DCDate))
+		latestItems = append(latestItems, models.LatestItem{
+			Title:        strings.TrimSpace(item.Title),
+			Link:         strings.TrimSpace(item.Link),
+			Category:     config.Category,
+			Description:  summarizeText(item.Description),
+			Author:       firstNonEmpty(item.DCCreator, rssAuthorName(item.Author)),
+			PublishedAt:  date,
+			UnparsedDate: unparsedDate,
+		})
+	}
+
+	sortItemsByDate(latestItems)
+	return latestItems, nil
+}
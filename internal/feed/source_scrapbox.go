@@ -0,0 +1,13 @@
+package feed
+
+import "fmt"
+
+func init() {
+	// scrapbox: Scrapbox project feed (project name as feedUrl)
+	RegisterSource("scrapbox", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return fmt.Sprintf("%s/api/feed/%s", baseURLs.Scrapbox, feedURL)
+		},
+		parse: parseRSSFeed,
+	})
+}
@@ -0,0 +1,222 @@
+package feed
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceTypes(t *testing.T) {
+	assert.Equal(t, []string{
+		"categoryIsAtomUrl",
+		"categoryIsUrl",
+		"connpass",
+		"feed",
+		"github-issues",
+		"github-releases",
+		"hatena",
+		"jsonfeed",
+		"note",
+		"qiita",
+		"rdf",
+		"scrapbox",
+		"zenn",
+	}, SourceTypes())
+}
+
+func TestRegisterSource_Duplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterSource("zenn", feedSource{})
+	})
+}
+
+// stubSource is a Source that returns fixed items
+type stubSource struct {
+	items []models.LatestItem
+}
+
+func (s stubSource) FeedURL(baseURLs BaseURLs, config models.FeedConfig) string {
+	return "https://stub.example.com/" + config.FeedURL
+}
+
+func (s stubSource) Fetch(f *Fetcher, config models.FeedConfig) ([]models.LatestItem, error) {
+	return s.items, nil
+}
+
+func TestRegisterSource_CustomType(t *testing.T) {
+	RegisterSource("test-stub", stubSource{items: []models.LatestItem{
+		{Title: "Stub Article", Link: "https://stub.example.com/article"},
+	}})
+	defer func() {
+		sourcesMu.Lock()
+		delete(sources, "test-stub")
+		sourcesMu.Unlock()
+	}()
+
+	config := models.FeedConfig{Name: "Stub", Type: "test-stub", FeedURL: "feed"}
+	assert.Equal(t, "https://stub.example.com/feed", getFeedURL(config))
+
+	item, err := FetchLatestItem(config)
+	require.NoError(t, err)
+	assert.Equal(t, "Stub Article", item.Title)
+}
+
+func TestFetchItems_UnknownType(t *testing.T) {
+	_, err := NewFetcher(FetcherConfig{}).FetchItems(models.FeedConfig{Name: "Unknown", Type: "unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown feed type")
+}
+
+func TestFeedSource_Parsers(t *testing.T) {
+	atomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <title>Entry</title>
+    <link href="https://example.com/entry"/>
+    <updated>2023-11-06T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+	tests := []struct {
+		feedType string
+		isAtom   bool
+	}{
+		{"qiita", true},
+		{"connpass", true},
+		{"categoryIsAtomUrl", true},
+		{"zenn", false},
+		{"note", false},
+		{"hatena", false},
+		{"scrapbox", false},
+		// Generic feed types detect the format, so they accept Atom as well
+		{"categoryIsUrl", true},
+		{"feed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.feedType, func(t *testing.T) {
+			source, ok := LookupSource(tt.feedType)
+			require.True(t, ok)
+
+			resp := &http.Response{Body: io.NopCloser(strings.NewReader(atomXML))}
+			_, err := source.(feedSource).parse(resp, models.FeedConfig{})
+			assert.Equal(t, tt.isAtom, err == nil)
+		})
+	}
+}
+
+func TestGitHubIssuesSource(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"title": " Newest issue ", "html_url": "https://github.com/owner/repo/issues/2", "created_at": "2023-11-06T10:00:00Z"},
+			{"title": "Older issue", "html_url": "https://github.com/owner/repo/issues/1", "created_at": "2023-11-05T10:00:00Z"}
+		]`))
+	}))
+	defer server.Close()
+
+	baseURLs := DefaultBaseURLs()
+	baseURLs.GitHubAPI = server.URL
+	fetcher := NewFetcher(FetcherConfig{BaseURLs: &baseURLs, MinRequestInterval: -1})
+
+	items, err := fetcher.FetchItems(models.FeedConfig{Name: "Repo", Type: "github-issues", FeedURL: "owner/repo", Category: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/owner/repo/issues?state=all&sort=created&direction=desc&per_page=100", requestURI)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Newest issue", items[0].Title)
+	assert.Equal(t, "https://github.com/owner/repo/issues/2", items[0].Link)
+	assert.Equal(t, "test", items[0].Category)
+}
+
+func TestConnpassFeedURL(t *testing.T) {
+	assert.Equal(t, "https://group.connpass.com/ja.atom", connpassFeedURL("https://connpass.com", "group"))
+	assert.Equal(t, "http://group.localhost:8080/ja.atom", connpassFeedURL("http://localhost:8080/", "group"))
+	assert.Equal(t, "", connpassFeedURL("not a url", "group"))
+}
+
+func TestDetectFeedFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    feedFormat
+	}{
+		{
+			name:     "RSS 2.0",
+			body:     `<?xml version="1.0"?><rss version="2.0"><channel></channel></rss>`,
+			expected: formatRSS,
+		},
+		{
+			name:        "Atom served as text/xml",
+			contentType: "text/xml; charset=utf-8",
+			body:        `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"></feed>`,
+			expected:    formatAtom,
+		},
+		{
+			name:     "RSS 1.0",
+			body:     `<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"></rdf:RDF>`,
+			expected: formatRDF,
+		},
+		{
+			name:     "root element after comments and doctype",
+			body:     `<?xml version="1.0"?><!-- generator --><!DOCTYPE rss><rss version="2.0"></rss>`,
+			expected: formatRSS,
+		},
+		{
+			name:     "Shift_JIS declaration",
+			body:     `<?xml version="1.0" encoding="Shift_JIS"?><rss version="2.0"></rss>`,
+			expected: formatRSS,
+		},
+		{
+			name:        "Content-Type fallback",
+			contentType: "application/atom+xml",
+			body:        `not xml`,
+			expected:    formatAtom,
+		},
+		{
+			name:        "unknown",
+			contentType: "text/html",
+			body:        `<html><body></body></html>`,
+			expected:    formatUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectFeedFormat(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+func TestFetchLatestItem_DetectsAtomForRSSType(t *testing.T) {
+	atomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <title>Atom Entry</title>
+    <link href="https://example.com/atom-entry"/>
+    <updated>2023-11-06T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(atomXML))
+	}))
+	defer server.Close()
+
+	// An Atom feed configured as categoryIsUrl (RSS) still works
+	item, err := FetchLatestItem(models.FeedConfig{Name: "Atom Feed", Type: "categoryIsUrl", FeedURL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "Atom Entry", item.Title)
+	assert.Equal(t, "https://example.com/atom-entry", item.Link)
+}
@@ -0,0 +1,13 @@
+package feed
+
+import "fmt"
+
+func init() {
+	// zenn: Zenn user feed (username as feedUrl)
+	RegisterSource("zenn", feedSource{
+		buildURL: func(baseURLs BaseURLs, feedURL string) string {
+			return fmt.Sprintf("%s/%s/feed", baseURLs.Zenn, feedURL)
+		},
+		parse: parseRSSFeed,
+	})
+}
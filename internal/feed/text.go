@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDescriptionLength is the maximum length of an item description in characters
+const maxDescriptionLength = 300
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	// rssAuthorPattern matches the RSS 2.0 author format "email (Name)"
+	rssAuthorPattern = regexp.MustCompile(`^\S+@\S+\s*\((.+)\)$`)
+)
+
+// summarizeText turns an HTML or plain text description into a short plain text summary
+func summarizeText(text string) string {
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	text = strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+
+	if utf8.RuneCountInString(text) <= maxDescriptionLength {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:maxDescriptionLength])) + "…"
+}
+
+// firstNonEmpty returns the first non-blank value (trimmed)
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// rssAuthorName extracts the name from an RSS 2.0 author ("email (Name)" or a plain name)
+func rssAuthorName(author string) string {
+	author = strings.TrimSpace(author)
+	if match := rssAuthorPattern.FindStringSubmatch(author); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return author
+}
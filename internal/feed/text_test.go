@@ -0,0 +1,40 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain text", "  Plain   text\n with newlines ", "Plain text with newlines"},
+		{"html", `<p>Hello <a href="https://example.com">world</a></p><p>Next</p>`, "Hello world Next"},
+		{"entities", "Tom &amp; Jerry &lt;3", "Tom & Jerry <3"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, summarizeText(tt.text))
+		})
+	}
+}
+
+func TestSummarizeText_Truncates(t *testing.T) {
+	result := summarizeText(strings.Repeat("あ", maxDescriptionLength+10))
+	assert.Equal(t, maxDescriptionLength+1, utf8.RuneCountInString(result))
+	assert.True(t, strings.HasSuffix(result, "…"))
+}
+
+func TestRSSAuthorName(t *testing.T) {
+	assert.Equal(t, "Taro Yamada", rssAuthorName("taro@example.com (Taro Yamada)"))
+	assert.Equal(t, "Taro Yamada", rssAuthorName(" Taro Yamada "))
+	assert.Equal(t, "taro@example.com", rssAuthorName("taro@example.com"))
+}
@@ -0,0 +1,202 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/internal/feed"
+	"tech-feed-weekly/pkg/models"
+	"text/tabwriter"
+	"time"
+)
+
+// DefaultStaleMonths is the number of months without a new item after which a feed is reported as stale
+const DefaultStaleMonths = 6
+
+// DefaultTopProducers is the number of most productive feeds listed per category
+const DefaultTopProducers = 5
+
+// Options represents the settings of a report
+type Options struct {
+	StaleMonths  int // Months without a new item after which a feed is stale (0 means DefaultStaleMonths)
+	TopProducers int // Most productive feeds listed per category (0 means DefaultTopProducers)
+}
+
+// Report represents the health of the configured feeds grouped by category
+type Report struct {
+	GeneratedAt time.Time        `json:"generatedAt"`
+	StaleMonths int              `json:"staleMonths"`
+	NoHealth    bool             `json:"noHealth,omitempty"` // No feed health was recorded, so every feed is unchecked
+	Categories  []CategoryReport `json:"categories"`
+}
+
+// CategoryReport represents the health of the feeds of one config file
+type CategoryReport struct {
+	Category      string       `json:"category"`
+	Feeds         int          `json:"feeds"`
+	Failing       []FeedStatus `json:"failing"`       // Feeds whose last fetch failed
+	Stale         []FeedStatus `json:"stale"`         // Feeds without a new item for StaleMonths
+	NeverProduced []FeedStatus `json:"neverProduced"` // Feeds that never produced an item since they were tracked
+	TopProducers  []FeedStatus `json:"topProducers"`  // Feeds that produced the most items
+	Unchecked     []FeedStatus `json:"unchecked"`     // Feeds the collector has not fetched yet
+}
+
+// FeedStatus represents the health of a single feed
+type FeedStatus struct {
+	Name                string    `json:"name"`
+	FeedURL             string    `json:"feedUrl"`
+	ConsecutiveFailures int       `json:"consecutiveFailures,omitempty"`
+	Quarantined         bool      `json:"quarantined,omitempty"`
+	Dead                bool      `json:"dead,omitempty"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastSuccess         time.Time `json:"lastSuccess,omitzero"`
+	LastNewItem         time.Time `json:"lastNewItem,omitzero"`
+	FirstChecked        time.Time `json:"firstChecked,omitzero"`
+	TotalItems          int       `json:"totalItems,omitempty"`
+}
+
+// Build creates the report of the configured feeds from the health state kept by the collector
+func Build(configMap map[string]*config.ConfigFileData, health *models.FeedHealthData, now time.Time, options Options) *Report {
+	if options.StaleMonths <= 0 {
+		options.StaleMonths = DefaultStaleMonths
+	}
+	if options.TopProducers <= 0 {
+		options.TopProducers = DefaultTopProducers
+	}
+	staleBefore := now.AddDate(0, -options.StaleMonths, 0)
+
+	var categoryNames []string
+	for categoryName := range configMap {
+		categoryNames = append(categoryNames, categoryName)
+	}
+	sort.Strings(categoryNames)
+
+	report := &Report{GeneratedAt: now, StaleMonths: options.StaleMonths, NoHealth: health == nil || len(health.Feeds) == 0}
+	for _, categoryName := range categoryNames {
+		configData := configMap[categoryName]
+		category := CategoryReport{Category: categoryName, Feeds: len(configData.Data)}
+
+		var producers []FeedStatus
+		for _, feedConfig := range configData.Data {
+			entry, tracked := models.FeedHealthEntry{}, false
+			if health != nil {
+				entry, tracked = health.Feeds[feed.FeedHealthKey(categoryName, feedConfig.Name)]
+			}
+			status := FeedStatus{
+				Name:                feedConfig.Name,
+				FeedURL:             feedConfig.FeedURL,
+				ConsecutiveFailures: entry.ConsecutiveFailures,
+				Quarantined:         entry.Quarantined,
+				Dead:                entry.Dead,
+				LastError:           entry.LastError,
+				LastSuccess:         entry.LastSuccess,
+				LastNewItem:         entry.LastNewItem,
+				FirstChecked:        entry.FirstChecked,
+				TotalItems:          entry.TotalItems,
+			}
+
+			if !tracked {
+				category.Unchecked = append(category.Unchecked, status)
+				continue
+			}
+			if entry.ConsecutiveFailures > 0 {
+				category.Failing = append(category.Failing, status)
+			}
+			if entry.TotalItems == 0 {
+				category.NeverProduced = append(category.NeverProduced, status)
+			} else {
+				producers = append(producers, status)
+				if entry.LastNewItem.Before(staleBefore) {
+					category.Stale = append(category.Stale, status)
+				}
+			}
+		}
+
+		sort.SliceStable(producers, func(i, j int) bool {
+			return producers[i].TotalItems > producers[j].TotalItems
+		})
+		if len(producers) > options.TopProducers {
+			producers = producers[:options.TopProducers]
+		}
+		category.TopProducers = producers
+
+		report.Categories = append(report.Categories, category)
+	}
+
+	return report
+}
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// WriteTable writes the report as human readable tables, one section per category
+func WriteTable(w io.Writer, report *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if report.NoHealth {
+		fmt.Fprintln(tw, "Note: no feed health recorded, every feed is listed as not checked yet.")
+		fmt.Fprintln(tw, "Run the collector or download the feed-health artifact of the Feed Collector workflow first.")
+		fmt.Fprintln(tw)
+	}
+
+	for i, category := range report.Categories {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "== %s (%d feeds)\n", category.Category, category.Feeds)
+
+		writeSection(tw, "Failing", category.Failing, "FAILURES\tLAST ERROR", func(status FeedStatus) string {
+			failures := fmt.Sprintf("%d", status.ConsecutiveFailures)
+			if status.Dead {
+				failures += " (dead)"
+			} else if status.Quarantined {
+				failures += " (quarantined)"
+			}
+			return failures + "\t" + status.LastError
+		})
+		writeSection(tw, fmt.Sprintf("No new item for %d months", report.StaleMonths), category.Stale, "LAST NEW ITEM\tITEMS", func(status FeedStatus) string {
+			return fmt.Sprintf("%s\t%d", formatDate(status.LastNewItem), status.TotalItems)
+		})
+		writeSection(tw, "Never produced an item", category.NeverProduced, "TRACKED SINCE\tLAST SUCCESS", func(status FeedStatus) string {
+			return formatDate(status.FirstChecked) + "\t" + formatDate(status.LastSuccess)
+		})
+		writeSection(tw, "Top producers", category.TopProducers, "ITEMS\tLAST NEW ITEM", func(status FeedStatus) string {
+			return fmt.Sprintf("%d\t%s", status.TotalItems, formatDate(status.LastNewItem))
+		})
+		writeSection(tw, "Not checked yet", category.Unchecked, "FEED URL", func(status FeedStatus) string {
+			return status.FeedURL
+		})
+	}
+
+	return tw.Flush()
+}
+
+// writeSection writes a titled table of feeds, skipping empty sections
+func writeSection(w io.Writer, title string, feeds []FeedStatus, header string, columns func(FeedStatus) string) {
+	if len(feeds) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", title)
+	fmt.Fprintf(w, "  NAME\t%s\n", header)
+	for _, status := range feeds {
+		fmt.Fprintf(w, "  %s\t%s\n", status.Name, strings.ReplaceAll(columns(status), "\n", " "))
+	}
+}
+
+// formatDate formats a date for the table ("-" for a zero date)
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
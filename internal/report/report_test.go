@@ -0,0 +1,121 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"tech-feed-weekly/internal/config"
+	"tech-feed-weekly/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReport() *Report {
+	now := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	configMap := map[string]*config.ConfigFileData{
+		"company": {Category: "company", Data: []models.FeedConfig{
+			{Name: "Active", FeedURL: "https://active.example.com/feed"},
+			{Name: "Busy", FeedURL: "https://busy.example.com/feed"},
+			{Name: "Broken", FeedURL: "https://broken.example.com/feed"},
+			{Name: "Quiet", FeedURL: "https://quiet.example.com/feed"},
+			{Name: "Silent", FeedURL: "https://silent.example.com/feed"},
+			{Name: "New", FeedURL: "https://new.example.com/feed"},
+		}},
+		"community": {Category: "community", Data: []models.FeedConfig{
+			{Name: "Meetup", FeedURL: "meetup"},
+		}},
+	}
+	health := &models.FeedHealthData{Feeds: map[string]models.FeedHealthEntry{
+		"company/Active": {TotalItems: 3, LastNewItem: now.AddDate(0, 0, -3)},
+		"company/Busy":   {TotalItems: 12, LastNewItem: now.AddDate(0, 0, -1)},
+		"company/Broken": {TotalItems: 1, LastNewItem: now.AddDate(0, -1, 0), ConsecutiveFailures: 6, Quarantined: true, LastError: "HTTP error 404"},
+		"company/Quiet":  {TotalItems: 2, LastNewItem: now.AddDate(-1, 0, 0)},
+		"company/Silent": {FirstChecked: now.AddDate(0, -8, 0)},
+		// Feeds removed from the config are ignored
+		"company/Removed":  {ConsecutiveFailures: 3},
+		"community/Meetup": {TotalItems: 1, LastNewItem: now.AddDate(0, -2, 0)},
+	}}
+
+	return Build(configMap, health, now, Options{StaleMonths: 6, TopProducers: 2})
+}
+
+func feedNames(feeds []FeedStatus) []string {
+	names := []string{}
+	for _, status := range feeds {
+		names = append(names, status.Name)
+	}
+	return names
+}
+
+func TestBuild(t *testing.T) {
+	report := newTestReport()
+
+	require.Len(t, report.Categories, 2)
+	assert.Equal(t, "community", report.Categories[0].Category)
+	assert.Equal(t, []string{"Meetup"}, feedNames(report.Categories[0].TopProducers))
+
+	company := report.Categories[1]
+	assert.Equal(t, 6, company.Feeds)
+	assert.Equal(t, []string{"Broken"}, feedNames(company.Failing))
+	assert.True(t, company.Failing[0].Quarantined)
+	assert.Equal(t, []string{"Quiet"}, feedNames(company.Stale))
+	assert.Equal(t, []string{"Silent"}, feedNames(company.NeverProduced))
+	assert.Equal(t, []string{"Busy", "Active"}, feedNames(company.TopProducers))
+	assert.Equal(t, []string{"New"}, feedNames(company.Unchecked))
+}
+
+func TestBuild_Defaults(t *testing.T) {
+	report := Build(map[string]*config.ConfigFileData{}, nil, time.Now(), Options{})
+	assert.Equal(t, DefaultStaleMonths, report.StaleMonths)
+	assert.Empty(t, report.Categories)
+	assert.True(t, report.NoHealth)
+	assert.False(t, newTestReport().NoHealth)
+}
+
+func TestWriteTable_NoHealth(t *testing.T) {
+	configMap := map[string]*config.ConfigFileData{
+		"company": {Category: "company", Data: []models.FeedConfig{{Name: "New", FeedURL: "https://new.example.com/feed"}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, Build(configMap, &models.FeedHealthData{}, time.Now(), Options{})))
+
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "Note: no feed health recorded"))
+	assert.Regexp(t, `New\s+https://new.example.com/feed`, output)
+
+	buf.Reset()
+	require.NoError(t, WriteTable(&buf, newTestReport()))
+	assert.NotContains(t, buf.String(), "Note:")
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, newTestReport()))
+
+	output := buf.String()
+	assert.Contains(t, output, "== company (6 feeds)")
+	assert.Contains(t, output, "Failing:")
+	assert.Regexp(t, `Broken\s+6 \(quarantined\)\s+HTTP error 404`, output)
+	assert.Contains(t, output, "No new item for 6 months:")
+	assert.Regexp(t, `Quiet\s+2024-12-01\s+2`, output)
+	assert.Regexp(t, `Silent\s+2025-04-01\s+-`, output)
+	assert.Regexp(t, `Busy\s+12\s+2025-11-30`, output)
+	assert.Regexp(t, `New\s+https://new.example.com/feed`, output)
+	// Empty sections are not printed
+	community := output[:strings.Index(output, "== company")]
+	assert.NotContains(t, community, "Failing:")
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, newTestReport()))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Categories, 2)
+	assert.Equal(t, "HTTP error 404", decoded.Categories[1].Failing[0].LastError)
+	assert.Equal(t, 12, decoded.Categories[1].TopProducers[0].TotalItems)
+}
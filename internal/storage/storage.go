@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"tech-feed-weekly/internal/canonical"
 	"tech-feed-weekly/pkg/models"
 )
 
@@ -55,10 +56,13 @@ func SaveLatestItems(filePath string, items *models.LatestItems) error {
 }
 
 // AddLatestItem adds a new item to latest items if it doesn't already exist
+// Links are compared and stored in canonical form
 func AddLatestItem(items *models.LatestItems, newItem models.LatestItem) bool {
+	newItem.Link = canonical.URL(newItem.Link)
+
 	// Check if item already exists
 	for _, item := range items.Items {
-		if item.Link == newItem.Link {
+		if canonical.URL(item.Link) == newItem.Link {
 			return false // Item already exists
 		}
 	}
@@ -66,4 +70,124 @@ func AddLatestItem(items *models.LatestItems, newItem models.LatestItem) bool {
 	// Add new item
 	items.Items = append(items.Items, newItem)
 	return true // Item was added
-}
\ No newline at end of file
+}
+
+// LoadHTTPCache loads the HTTP validator cache from the JSON file
+// Returns an empty cache if the file does not exist
+func LoadHTTPCache(filePath string) (*models.HTTPCache, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &models.HTTPCache{Entries: map[string]models.HTTPCacheEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read http cache file: %w", err)
+	}
+
+	var cache models.HTTPCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse http cache JSON: %w", err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]models.HTTPCacheEntry{}
+	}
+
+	return &cache, nil
+}
+
+// SaveHTTPCache saves the HTTP validator cache to the JSON file
+func SaveHTTPCache(filePath string, cache *models.HTTPCache) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for http cache: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal http cache: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write http cache file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadHatenaBookmarkHistory loads the Hatena Bookmark count history from the JSON file
+// Returns an empty history if the file does not exist
+func LoadHatenaBookmarkHistory(filePath string) (*models.HatenaBookmarkHistory, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &models.HatenaBookmarkHistory{Entries: map[string][]models.HatenaBookmarkSample{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Hatena Bookmark history file: %w", err)
+	}
+
+	var history models.HatenaBookmarkHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse Hatena Bookmark history JSON: %w", err)
+	}
+	if history.Entries == nil {
+		history.Entries = map[string][]models.HatenaBookmarkSample{}
+	}
+
+	return &history, nil
+}
+
+// SaveHatenaBookmarkHistory saves the Hatena Bookmark count history to the JSON file
+func SaveHatenaBookmarkHistory(filePath string, history *models.HatenaBookmarkHistory) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for Hatena Bookmark history: %w", err)
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal Hatena Bookmark history: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write Hatena Bookmark history file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFeedHealth loads the feed health state from the JSON file
+// Returns an empty state if the file does not exist
+func LoadFeedHealth(filePath string) (*models.FeedHealthData, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &models.FeedHealthData{Feeds: map[string]models.FeedHealthEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed health file: %w", err)
+	}
+
+	var health models.FeedHealthData
+	if err := json.Unmarshal(data, &health); err != nil {
+		return nil, fmt.Errorf("failed to parse feed health JSON: %w", err)
+	}
+	if health.Feeds == nil {
+		health.Feeds = map[string]models.FeedHealthEntry{}
+	}
+
+	return &health, nil
+}
+
+// SaveFeedHealth saves the feed health state to the JSON file
+func SaveFeedHealth(filePath string, health *models.FeedHealthData) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for feed health: %w", err)
+	}
+
+	data, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal feed health: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write feed health file: %w", err)
+	}
+
+	return nil
+}
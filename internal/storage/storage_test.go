@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 	"tech-feed-weekly/pkg/models"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -180,4 +181,145 @@ func TestAddLatestItem_EmptyItems(t *testing.T) {
 	assert.True(t, added)
 	assert.Len(t, items.Items, 1)
 	assert.Equal(t, "First Article", items.Items[0].Title)
-}
\ No newline at end of file
+}
+
+func TestLoadHTTPCache_NewFile(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "http-cache.json")
+
+	cache, err := LoadHTTPCache(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Entries)
+}
+
+func TestSaveAndLoadHTTPCache(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "data", "http-cache.json")
+
+	cache := &models.HTTPCache{
+		Entries: map[string]models.HTTPCacheEntry{
+			"https://example.com/feed": {ETag: `"abc"`, LastModified: "Mon, 06 Nov 2023 10:00:00 GMT"},
+		},
+	}
+
+	err := SaveHTTPCache(filePath, cache)
+	require.NoError(t, err)
+
+	loaded, err := LoadHTTPCache(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, cache.Entries, loaded.Entries)
+}
+
+func TestLoadHatenaBookmarkHistory_NewFile(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "hatena-bookmark-history.json")
+
+	history, err := LoadHatenaBookmarkHistory(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, history.Entries)
+}
+
+func TestSaveAndLoadHatenaBookmarkHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "data", "hatena-bookmark-history.json")
+
+	history := &models.HatenaBookmarkHistory{
+		Entries: map[string][]models.HatenaBookmarkSample{
+			"https://example.com/article": {
+				{Count: 10, Time: time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC)},
+				{Count: 70, Time: time.Date(2023, 11, 6, 11, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	err := SaveHatenaBookmarkHistory(filePath, history)
+	require.NoError(t, err)
+
+	loaded, err := LoadHatenaBookmarkHistory(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, history.Entries, loaded.Entries)
+}
+
+func TestAddLatestItem_CanonicalLink(t *testing.T) {
+	items := &models.LatestItems{
+		Items: []models.LatestItem{
+			{Title: "First Article", Link: "https://example.com/article1/"},
+		},
+	}
+
+	added := AddLatestItem(items, models.LatestItem{Title: "First Article", Link: "http://example.com/article1?utm_source=rss#comments"})
+	assert.False(t, added)
+
+	added = AddLatestItem(items, models.LatestItem{Title: "Second Article", Link: "https://EXAMPLE.com/article2/?utm_campaign=weekly"})
+	assert.True(t, added)
+	assert.Equal(t, "https://example.com/article2", items.Items[1].Link)
+}
+
+func TestLoadLatestItems_WithoutMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "latest-items.json")
+
+	// Files written before descriptions, authors and dates were stored still load
+	oldData := `{"items": [{"title": "Old Article", "link": "https://example.com/old", "category": "test"}]}`
+	require.NoError(t, os.WriteFile(filePath, []byte(oldData), 0644))
+
+	items, err := LoadLatestItems(filePath)
+	require.NoError(t, err)
+	require.Len(t, items.Items, 1)
+	assert.Equal(t, "Old Article", items.Items[0].Title)
+	assert.True(t, items.Items[0].PublishedAt.IsZero())
+
+	// Items without metadata are saved without the new fields
+	require.NoError(t, SaveLatestItems(filePath, items))
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "publishedAt")
+	assert.NotContains(t, string(data), "feedName")
+}
+
+func TestSaveAndLoadLatestItems_Metadata(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "latest-items.json")
+
+	item := models.LatestItem{
+		Title:       "Article",
+		Link:        "https://example.com/article",
+		Category:    "test",
+		Description: "Summary of the article",
+		Author:      "Taro",
+		PublishedAt: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC),
+		FeedName:    "Example Blog",
+	}
+	require.NoError(t, SaveLatestItems(filePath, &models.LatestItems{Items: []models.LatestItem{item}}))
+
+	loaded, err := LoadLatestItems(filePath)
+	require.NoError(t, err)
+	require.Len(t, loaded.Items, 1)
+	assert.Equal(t, item, loaded.Items[0])
+}
+
+func TestLoadFeedHealth_NewFile(t *testing.T) {
+	health, err := LoadFeedHealth(filepath.Join(t.TempDir(), "feed-health.json"))
+	require.NoError(t, err)
+	assert.Empty(t, health.Feeds)
+}
+
+func TestSaveAndLoadFeedHealth(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data", "feed-health.json")
+	health := &models.FeedHealthData{Feeds: map[string]models.FeedHealthEntry{
+		"company/Blog": {
+			Name:                "Blog",
+			Category:            "company",
+			LastChecked:         time.Date(2023, 11, 6, 10, 0, 0, 0, time.UTC),
+			LastError:           "HTTP error 404",
+			ConsecutiveFailures: 5,
+			Quarantined:         true,
+		},
+	}}
+
+	require.NoError(t, SaveFeedHealth(filePath, health))
+
+	loaded, err := LoadFeedHealth(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, health, loaded)
+}
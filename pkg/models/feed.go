@@ -7,11 +7,19 @@ import (
 
 // FeedConfig represents a feed configuration loaded from JSON file
 type FeedConfig struct {
-	Name            string `json:"name"`
-	Type            string `json:"type"`
-	FeedURL         string `json:"feedUrl"`
-	LatestLink      string `json:"latestLink"`
-	Category        string `json:"-"` // File name without extension
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	FeedURL         string   `json:"feedUrl"`
+	LatestLink      string   `json:"latestLink"`
+	MaxItems        int      `json:"maxItems,omitempty"`        // Max new items per run (0 means default)
+	MaxAge          string   `json:"maxAge,omitempty"`          // Ignore entries older than this Go duration (empty means the global setting, "0" disables it)
+	SkipPrereleases bool     `json:"skipPrereleases,omitempty"` // github-releases: ignore prereleases
+	IssueKind       string   `json:"issueKind,omitempty"`       // github-issues: "issue" or "pr" (both when empty)
+	IncludeLabels   []string `json:"includeLabels,omitempty"`   // github-issues: keep only items with any of these labels
+	ExcludeLabels   []string `json:"excludeLabels,omitempty"`   // github-issues: drop items with any of these labels
+	Include         []string `json:"include,omitempty"`         // Keep only entries whose title or description matches any of these keywords or /regexps/
+	Exclude         []string `json:"exclude,omitempty"`         // Drop entries whose title or description matches any of these keywords or /regexps/
+	Category        string   `json:"-"`                         // File name without extension
 }
 
 // FeedData represents the data structure for feeds configuration
@@ -21,9 +29,28 @@ type FeedData struct {
 
 // LatestItem represents an item in latest-items.json
 type LatestItem struct {
+	Title       string       `json:"title"`
+	Link        string       `json:"link"`
+	Category    string       `json:"category"`
+	Description string       `json:"description,omitempty"`
+	Number      int          `json:"number,omitempty"` // GitHub issue or pull request number
+	Author      string       `json:"author,omitempty"`
+	Labels      []string     `json:"labels,omitempty"`
+	Kind        string       `json:"kind,omitempty"`       // GitHub item kind: "issue" or "pr"
+	Bookmarks   int          `json:"bookmarks,omitempty"`  // Hatena Bookmark count when collected
+	PublishedAt time.Time    `json:"publishedAt,omitzero"` // Publish date reported by the feed (zero if unknown)
+	FeedName    string       `json:"feedName,omitempty"`   // Name of the feed the item was collected from
+	Sources     []ItemSource `json:"sources,omitempty"`    // Other sources the same article was collected from
+
+	UnparsedDate string `json:"-"` // Date given by the feed that could not be parsed (not persisted)
+}
+
+// ItemSource represents another source an item was collected from
+type ItemSource struct {
 	Title    string `json:"title"`
 	Link     string `json:"link"`
 	Category string `json:"category"`
+	FeedName string `json:"feedName,omitempty"`
 }
 
 // LatestItems represents the structure of latest-items.json
@@ -33,23 +60,37 @@ type LatestItems struct {
 
 // RSSItem represents an item from RSS feed
 type RSSItem struct {
-	Title   string    `xml:"title"`
-	Link    string    `xml:"link"`
-	PubDate string    `xml:"pubDate"`
-	Date    time.Time `xml:"-"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Author      string `xml:"author"`
+	DCCreator   string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	PubDate     string `xml:"pubDate"`
+	DCDate      string `xml:"http://purl.org/dc/elements/1.1/ date"`
 }
 
 // AtomEntry represents an entry from Atom feed
 type AtomEntry struct {
-	Title   string    `xml:"title"`
-	Link    AtomLink  `xml:"link"`
-	Updated string    `xml:"updated"`
-	Date    time.Time `xml:"-"`
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Links     []AtomLink   `xml:"link"`
+	Summary   string       `xml:"summary"`
+	Content   string       `xml:"content"`
+	Authors   []AtomPerson `xml:"author"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+}
+
+// AtomPerson represents an author in Atom feed
+type AtomPerson struct {
+	Name string `xml:"name"`
 }
 
 // AtomLink represents a link in Atom feed
 type AtomLink struct {
 	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
 }
 
 // RSSFeed represents RSS feed structure
@@ -67,13 +108,29 @@ type AtomFeed struct {
 	Entries []AtomEntry `xml:"entry"`
 }
 
+// RDFFeed represents RSS 1.0 (RDF) feed structure
+type RDFFeed struct {
+	XMLName xml.Name  `xml:"RDF"`
+	Items   []RDFItem `xml:"item"`
+}
+
+// RDFItem represents an item from RSS 1.0 (RDF) feed
+type RDFItem struct {
+	About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	DCCreator   string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	DCDate      string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	PubDate     string `xml:"pubDate"`
+}
+
 // HatenaBookmarkItem represents an item from Hatena Bookmark RSS feed
 type HatenaBookmarkItem struct {
-	Title         string    `xml:"title"`
-	Link          string    `xml:"link"`
-	PubDate       string    `xml:"pubDate"`
-	BookmarkCount int       `xml:"bookmarkcount"`
-	Date          time.Time `xml:"-"`
+	Title         string `xml:"title"`
+	Link          string `xml:"link"`
+	PubDate       string `xml:"pubDate"`
+	BookmarkCount int    `xml:"bookmarkcount"`
 }
 
 // HatenaBookmarkFeed represents Hatena Bookmark RSS feed structure
@@ -88,6 +145,38 @@ type HatenaBookmarkFeed struct {
 
 
 
+// HatenaBookmarkConfig represents the Hatena Bookmark aggregation settings loaded from JSON file
+type HatenaBookmarkConfig struct {
+	Categories []HatenaBookmarkCategory `json:"categories"`
+}
+
+// HatenaBookmarkCategory represents a Hatena Bookmark hotentry category to aggregate
+type HatenaBookmarkCategory struct {
+	Name             string                    `json:"name"`                       // Hotentry category (e.g. "it", "all")
+	Category         string                    `json:"category"`                   // Category of the collected items
+	Threshold        int                       `json:"threshold"`                  // Items need more bookmarks than this
+	DomainThresholds map[string]int            `json:"domainThresholds,omitempty"` // Per-domain thresholds (subdomains included)
+	DenyDomains      []string                  `json:"denyDomains,omitempty"`      // Domains whose items are never collected
+	Rising           *HatenaBookmarkRisingRule `json:"rising,omitempty"`           // Also collect items gaining bookmarks fast
+}
+
+// HatenaBookmarkRisingRule collects items whose bookmark count grew by MinIncrease within Window
+type HatenaBookmarkRisingRule struct {
+	MinIncrease int    `json:"minIncrease"`
+	Window      string `json:"window"` // Go duration (e.g. "3h")
+}
+
+// HatenaBookmarkSample represents the bookmark count of an entry at a point in time
+type HatenaBookmarkSample struct {
+	Count int       `json:"count"`
+	Time  time.Time `json:"time"`
+}
+
+// HatenaBookmarkHistory represents the structure of hatena-bookmark-history.json (keyed by entry URL)
+type HatenaBookmarkHistory struct {
+	Entries map[string][]HatenaBookmarkSample `json:"entries"`
+}
+
 // GitHubIssue represents an issue from the GitHub API
 
 type GitHubIssue struct {
@@ -98,4 +187,117 @@ type GitHubIssue struct {
 
 	CreatedAt time.Time `json:"created_at"`
 
-}
\ No newline at end of file
+	Number int `json:"number"`
+
+	State string `json:"state"` // "open" or "closed"
+
+	User GitHubUser `json:"user"`
+
+	Labels []GitHubLabel `json:"labels"`
+
+	PullRequest *GitHubPullRequestRef `json:"pull_request"` // Set only for pull requests
+
+	Body string `json:"body"`
+
+}
+
+// GitHubUser represents a user from the GitHub API
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+// GitHubLabel represents an issue label from the GitHub API
+type GitHubLabel struct {
+	Name string `json:"name"`
+}
+
+// GitHubPullRequestRef represents the pull request part of an issue from the GitHub API
+type GitHubPullRequestRef struct {
+	HTMLURL string `json:"html_url"`
+}
+
+// GitHubRelease represents a release from the GitHub API
+type GitHubRelease struct {
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name"`
+	HTMLURL     string     `json:"html_url"`
+	Draft       bool       `json:"draft"`
+	Prerelease  bool       `json:"prerelease"`
+	PublishedAt time.Time  `json:"published_at"`
+	Author      GitHubUser `json:"author"`
+	Body        string     `json:"body"`
+}
+
+// JSONFeed represents JSON Feed (https://jsonfeed.org/version/1.1) structure
+type JSONFeed struct {
+	Version string         `json:"version"`
+	Title   string         `json:"title"`
+	Items   []JSONFeedItem `json:"items"`
+}
+
+// JSONFeedItem represents an item from JSON Feed
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	Summary       string           `json:"summary"`
+	ContentText   string           `json:"content_text"`
+	Authors       []JSONFeedAuthor `json:"authors"`
+	Author        *JSONFeedAuthor  `json:"author"` // JSON Feed 1.0
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+}
+
+// URLCanonicalizationConfig represents the URL canonicalization rules loaded from JSON file
+type URLCanonicalizationConfig struct {
+	StripParams []string                 `json:"stripParams"`       // Query params removed from every URL ("utm_*" matches a prefix)
+	Domains     map[string]URLDomainRule `json:"domains,omitempty"` // Rules for specific domains (subdomains included)
+}
+
+// URLDomainRule represents the canonicalization rule of a domain
+type URLDomainRule struct {
+	StripParams       []string `json:"stripParams,omitempty"`       // Additional query params removed
+	KeepParams        []string `json:"keepParams,omitempty"`        // Query params never removed
+	KeepFragment      bool     `json:"keepFragment,omitempty"`      // Keep the #fragment (e.g. hash routed sites)
+	KeepTrailingSlash bool     `json:"keepTrailingSlash,omitempty"` // Keep a trailing slash in the path
+	KeepScheme        bool     `json:"keepScheme,omitempty"`        // Do not upgrade http to https
+}
+
+// JSONFeedAuthor represents an author in JSON Feed
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// HTTPCacheEntry represents the validators of a previous HTTP response
+type HTTPCacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// HTTPCache represents the structure of http-cache.json (keyed by request URL)
+type HTTPCache struct {
+	Entries map[string]HTTPCacheEntry `json:"entries"`
+}
+
+// FeedHealthEntry represents the health of a feed across collector runs
+type FeedHealthEntry struct {
+	Name                string    `json:"name"`
+	Category            string    `json:"category"`
+	FeedURL             string    `json:"feedUrl,omitempty"`             // feedUrl the health was recorded for
+	FirstChecked        time.Time `json:"firstChecked,omitzero"`         // First run the feed was tracked in
+	LastChecked         time.Time `json:"lastChecked,omitzero"`          // Last time the feed was fetched
+	LastSuccess         time.Time `json:"lastSuccess,omitzero"`          // Last time the feed was fetched without error
+	LastError           string    `json:"lastError,omitempty"`           // Error of the last failed fetch
+	LastErrorAt         time.Time `json:"lastErrorAt,omitzero"`          // Time of the last failed fetch
+	ConsecutiveFailures int       `json:"consecutiveFailures,omitempty"` // Failed fetches since the last success
+	Quarantined         bool      `json:"quarantined,omitempty"`         // Skipped until a periodic re-probe succeeds
+	Dead                bool      `json:"dead,omitempty"`                // The feed answered 410 Gone and is no longer fetched
+	LastNewItem         time.Time `json:"lastNewItem,omitzero"`          // Last time a new item was collected
+	TotalItems          int       `json:"totalItems,omitempty"`          // Items collected since the feed was first tracked
+}
+
+// FeedHealthData represents the structure of feed-health.json (keyed by category and feed name)
+type FeedHealthData struct {
+	Feeds map[string]FeedHealthEntry `json:"feeds"`
+}
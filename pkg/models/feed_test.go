@@ -2,7 +2,6 @@ package models
 
 import (
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,33 +81,36 @@ func TestLatestItems(t *testing.T) {
 }
 
 func TestRSSItem(t *testing.T) {
-	testDate := time.Now()
 	item := RSSItem{
 		Title:   "RSS Article",
 		Link:    "https://example.com/rss-article",
 		PubDate: "Mon, 02 Jan 2006 15:04:05 MST",
-		Date:    testDate,
 	}
 
 	assert.Equal(t, "RSS Article", item.Title)
 	assert.Equal(t, "https://example.com/rss-article", item.Link)
 	assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 MST", item.PubDate)
-	assert.Equal(t, testDate, item.Date)
 }
 
 func TestAtomEntry(t *testing.T) {
-	testDate := time.Now()
 	entry := AtomEntry{
+		ID:    "tag:example.com,2006:1",
 		Title: "Atom Article",
-		Link: AtomLink{
-			Href: "https://example.com/atom-article",
+		Links: []AtomLink{
+			{
+				Href: "https://example.com/atom-article",
+				Rel:  "alternate",
+				Type: "text/html",
+			},
 		},
-		Updated: "2006-01-02T15:04:05Z",
-		Date:    testDate,
+		Published: "2006-01-01T15:04:05Z",
+		Updated:   "2006-01-02T15:04:05Z",
 	}
 
+	assert.Equal(t, "tag:example.com,2006:1", entry.ID)
 	assert.Equal(t, "Atom Article", entry.Title)
-	assert.Equal(t, "https://example.com/atom-article", entry.Link.Href)
+	assert.Equal(t, "https://example.com/atom-article", entry.Links[0].Href)
+	assert.Equal(t, "alternate", entry.Links[0].Rel)
+	assert.Equal(t, "2006-01-01T15:04:05Z", entry.Published)
 	assert.Equal(t, "2006-01-02T15:04:05Z", entry.Updated)
-	assert.Equal(t, testDate, entry.Date)
 }
\ No newline at end of file